@@ -0,0 +1,54 @@
+package graceful
+
+import (
+	"sync/atomic"
+
+	"github.com/rs/zerolog/log"
+)
+
+// AbandonPolicy behavior of shutdown process goroutine that is still running when its shutdown context is done.
+type AbandonPolicy int
+
+const (
+	// PolicyLeak leave the abandoned shutdown process running silently, it's the default policy.
+	PolicyLeak AbandonPolicy = iota
+	// PolicyDetach log the abandoned shutdown process and log its result once it's finished in the background.
+	PolicyDetach
+)
+
+// SetAbandonPolicy set abandon policy value.
+// shutdown process can't be stopped forcibly, so its context is the only way to ask it to return,
+// and the policy only decide how the abandoned goroutine is reported.
+func (g *Graceful) SetAbandonPolicy(policy AbandonPolicy) {
+	g.abandonPolicy = policy
+}
+
+// AbandonedGoroutines get number of abandoned shutdown process goroutines that are still running.
+func (g *Graceful) AbandonedGoroutines() int {
+	return int(atomic.LoadInt32(&g.abandoned))
+}
+
+// abandon track shutdown process goroutine that is still running after its shutdown context is done,
+// until its result is sent to errChan.
+func (g *Graceful) abandon(s shutdown, errChan <-chan error, run *shutdownRun) {
+	run.mutex.Lock()
+	run.abandoned++
+	run.mutex.Unlock()
+
+	atomic.AddInt32(&g.abandoned, 1)
+
+	detach := g.abandonPolicy == PolicyDetach
+	if detach {
+		log.Warn().Fields(g.logFields).Str(shutdownTag, s.tag).Msg(abandonedHookMessage)
+	}
+
+	go func() {
+		err := <-errChan
+
+		atomic.AddInt32(&g.abandoned, -1)
+
+		if detach {
+			log.Info().Fields(g.logFields).Str(shutdownTag, s.tag).AnErr(abandonedErrorTag, err).Msg(abandonedHookFinishedMessage)
+		}
+	}()
+}
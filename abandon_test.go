@@ -0,0 +1,65 @@
+package graceful
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetAbandonPolicy(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+	graceful.SetAbandonPolicy(PolicyDetach)
+
+	release := make(chan struct{})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		<-release
+
+		return nil
+	}, "stuck")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "healthy")
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), ErrShutdownTimeout)
+	assert.Equal(t, 1, graceful.LastShutdownReport().Abandoned)
+	assert.Equal(t, 1, graceful.AbandonedGoroutines())
+	assert.Contains(t, logs.String(), abandonedHookMessage)
+
+	close(release)
+
+	assert.Eventually(t, func() bool {
+		return graceful.AbandonedGoroutines() == 0
+	}, time.Second, 10*time.Millisecond)
+	assert.Eventually(t, func() bool {
+		return strings.Contains(logs.String(), abandonedHookFinishedMessage)
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGraceful_SetAbandonPolicyLeak(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		<-release
+
+		return nil
+	}, "stuck")
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), ErrShutdownTimeout)
+	assert.Equal(t, 1, graceful.LastShutdownReport().Abandoned)
+	assert.Equal(t, 1, graceful.AbandonedGoroutines())
+	assert.NotContains(t, logs.String(), abandonedHookMessage)
+}
@@ -0,0 +1,113 @@
+package graceful
+
+import (
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// adminState state of graceful that is dumped on /debug/graceful of admin server.
+type adminState struct {
+	State      string         `json:"state"`
+	EntryPoint string         `json:"entry_point,omitempty"`
+	Uptime     time.Duration  `json:"uptime"`
+	Pending    []string       `json:"pending_ready,omitempty"`
+	Config     Config         `json:"config"`
+	Report     ShutdownReport `json:"last_shutdown_report"`
+}
+
+// EnableAdminServer start admin http server on addr as background process with /healthz, /readyz
+// and /debug/graceful that dump state, configuration and the last shutdown report as json.
+// it's shut down on AdminServerPhase after all other shutdown process and before log flusher,
+// so it's still serving while the other shutdown process are draining.
+// it returns the address that admin server listens on, empty when it's failed to listen
+// and the listen error is returned as background process error.
+func (g *Graceful) EnableAdminServer(addr string) string {
+	const method = "EnableAdminServer"
+
+	listener, err := net.Listen("tcp", addr)
+	if err != nil {
+		g.RegisterProcess(func() error {
+			return err
+		})
+
+		return ""
+	}
+
+	server := &http.Server{
+		Handler:           g.adminHandler(),
+		ReadHeaderTimeout: adminReadHeaderTimeout,
+	}
+
+	shutdownProcess := newShutdown(adminServerTag, server.Shutdown)
+	shutdownProcess.phase = AdminServerPhase
+
+	if g.registerShutdown(method, shutdownProcess) == "" {
+		_ = listener.Close()
+
+		return ""
+	}
+
+	g.RegisterProcessIgnoringErrors(func() error {
+		return server.Serve(listener)
+	}, http.ErrServerClosed)
+
+	return listener.Addr().String()
+}
+
+// adminHandler get http handler of admin server.
+func (g *Graceful) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/readyz", func(w http.ResponseWriter, r *http.Request) {
+		if err := g.adminReady(); err != nil {
+			http.Error(w, err.Error(), http.StatusServiceUnavailable)
+
+			return
+		}
+
+		_, _ = w.Write([]byte("ok"))
+	})
+
+	mux.HandleFunc("/debug/graceful", func(w http.ResponseWriter, r *http.Request) {
+		g.mutex.Lock()
+		current := adminState{
+			State:      g.state.String(),
+			EntryPoint: g.entryPoint,
+			Pending:    g.pendingReady(),
+		}
+		g.mutex.Unlock()
+
+		current.Uptime = g.Uptime()
+		current.Config = g.Config()
+		current.Report = g.LastShutdownReport()
+
+		w.Header().Set("Content-Type", "application/json")
+		_ = json.NewEncoder(w).Encode(current)
+	})
+
+	return mux
+}
+
+// adminReady check whether declared processes are ready and shutdown process is not started.
+func (g *Graceful) adminReady() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.state >= stateShuttingDown {
+		return errors.New(g.state.String())
+	}
+
+	if pending := g.pendingReady(); len(pending) > 0 {
+		return errors.New("not ready: " + strings.Join(pending, ", "))
+	}
+
+	return nil
+}
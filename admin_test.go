@@ -0,0 +1,76 @@
+package graceful
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_EnableAdminServer(t *testing.T) {
+	graceful := New()
+	graceful.ExpectReady("database")
+
+	addr := graceful.EnableAdminServer("127.0.0.1:0")
+	assert.NotEmpty(t, addr)
+
+	get := func(path string) int {
+		response, err := http.Get("http://" + addr + path)
+		if !assert.Nil(t, err) {
+			return 0
+		}
+
+		defer response.Body.Close()
+
+		return response.StatusCode
+	}
+
+	assert.Equal(t, http.StatusOK, get("/healthz"))
+	assert.Equal(t, http.StatusServiceUnavailable, get("/readyz"))
+
+	graceful.MarkReady("database")
+	assert.Equal(t, http.StatusOK, get("/readyz"))
+
+	var (
+		healthDuringShutdown, readyDuringShutdown int
+		state                                     adminState
+	)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		healthDuringShutdown = get("/healthz")
+		readyDuringShutdown = get("/readyz")
+
+		response, err := http.Get("http://" + addr + "/debug/graceful")
+		if err != nil {
+			return err
+		}
+
+		defer response.Body.Close()
+
+		return json.NewDecoder(response.Body).Decode(&state)
+	}, "http-server")
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, http.StatusOK, healthDuringShutdown)
+	assert.Equal(t, http.StatusServiceUnavailable, readyDuringShutdown)
+	assert.Equal(t, "shutting-down", state.State)
+	assert.Equal(t, EntryPointStop, state.EntryPoint)
+
+	report := graceful.LastShutdownReport()
+	if assert.Len(t, report.Hooks, 2) {
+		assert.Equal(t, "http-server", report.Hooks[0].Tag)
+		assert.Equal(t, adminServerTag, report.Hooks[1].Tag)
+	}
+
+	_, err := http.Get("http://" + addr + "/healthz")
+	assert.NotNil(t, err)
+}
+
+func TestGraceful_EnableAdminServerListenError(t *testing.T) {
+	graceful := New()
+
+	assert.Empty(t, graceful.EnableAdminServer("invalid-address"))
+	assert.NotNil(t, graceful.Wait())
+}
@@ -0,0 +1,44 @@
+package graceful
+
+import (
+	"context"
+	"time"
+)
+
+// Clock source of time for shutdown timeouts, it can be replaced using SetClock to test timeout without waiting.
+type Clock interface {
+	// Now get current time.
+	Now() time.Time
+	// After get channel that receive the current time after duration.
+	After(duration time.Duration) <-chan time.Time
+	// WithTimeout get context that is done with context.DeadlineExceeded after timeout.
+	WithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc)
+}
+
+// realClock clock using the real time.
+type realClock struct{}
+
+// Now get current time.
+func (realClock) Now() time.Time {
+	return time.Now()
+}
+
+// After get channel that receive the current time after duration.
+func (realClock) After(duration time.Duration) <-chan time.Time {
+	return time.After(duration)
+}
+
+// WithTimeout get context that is done with context.DeadlineExceeded after timeout.
+func (realClock) WithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	return context.WithTimeout(parent, timeout)
+}
+
+// SetClock set clock for shutdown timeouts, start time and duration of shutdown process.
+// nil clock will reset it to the real clock.
+func (g *Graceful) SetClock(clock Clock) {
+	if clock == nil {
+		clock = realClock{}
+	}
+
+	g.clock = clock
+}
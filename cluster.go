@@ -0,0 +1,55 @@
+package graceful
+
+import (
+	"context"
+)
+
+// SetClusterLeaveFunc set cluster leave func that is run first once shutdown process is started,
+// before the drain delay and any shutdown process including connection drainers, e.g. to relinquish leadership
+// or leave the membership before the resources are stopped. its error is wrapped with ErrClusterLeave and returned
+// like other shutdown process, see SetAbortOnClusterLeaveError to skip the other shutdown process on error.
+// nil leave func will reset it.
+func (g *Graceful) SetClusterLeaveFunc(leave func(ctx context.Context) error) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.clusterLeave = leave
+}
+
+// SetAbortOnClusterLeaveError set abort on cluster leave error value.
+// when it's true, all shutdown process except the reserved phases, e.g. telemetry flush and log flusher,
+// are skipped as aborted when cluster leave func is failed, since stopping the resources of a node that is still
+// a member could cause split-brain. NonFatal error never abort. the default value is false.
+func (g *Graceful) SetAbortOnClusterLeaveError(value bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.abortOnLeaveError = value
+}
+
+// leaveCluster run cluster leave func as shutdown process and get whether other shutdown process must be aborted
+// with its error, it must be run before any other shutdown process since the error is taken from the shutdown run.
+func (g *Graceful) leaveCluster(ctx context.Context, run *shutdownRun) (abort bool, err error) {
+	g.mutex.Lock()
+	leave, abortOnError := g.clusterLeave, g.abortOnLeaveError
+	g.mutex.Unlock()
+
+	if leave == nil {
+		return false, nil
+	}
+
+	shutdownProcess := newShutdown(clusterLeaveTag, func(ctx context.Context) error {
+		if err := leave(ctx); err != nil {
+			return wrapSentinel(ErrClusterLeave, err)
+		}
+
+		return nil
+	})
+	shutdownProcess.id = g.idGenerator()
+
+	_ = g.runShutdownBatch(ctx, shutdownBatch{limit: 1, shutdowns: []shutdown{shutdownProcess}}, run)
+
+	err = run.failedErr()
+
+	return err != nil && abortOnError, err
+}
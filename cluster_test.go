@@ -0,0 +1,99 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetClusterLeaveFunc(t *testing.T) {
+	graceful := New()
+
+	var calls callRecorder
+
+	graceful.SetClusterLeaveFunc(calls.hook("leave"))
+	graceful.RegisterConnectionDrainer(calls.hook("drain"), "websocket")
+	graceful.RegisterShutdownProcess(calls.hook("database"))
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, []string{"leave", "drain", "database"}, calls.list())
+	assert.True(t, graceful.Config().ClusterLeave)
+}
+
+func TestGraceful_SetClusterLeaveFuncNoShutdownProcess(t *testing.T) {
+	graceful := New()
+
+	var left bool
+
+	graceful.SetClusterLeaveFunc(func(ctx context.Context) error {
+		left = true
+
+		return nil
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.True(t, left)
+}
+
+func TestGraceful_SetClusterLeaveFuncError(t *testing.T) {
+	leaveErr := errors.New("leave err")
+
+	for _, abort := range []bool{false, true} {
+		graceful := New()
+		graceful.SetReportAllErrors(true)
+		graceful.SetAbortOnClusterLeaveError(abort)
+		graceful.SetClusterLeaveFunc(func(ctx context.Context) error {
+			return leaveErr
+		})
+
+		var called, flushed bool
+
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			called = true
+
+			return nil
+		})
+
+		graceful.RegisterLogFlusher(func(ctx context.Context) error {
+			flushed = true
+
+			return nil
+		})
+
+		err := graceful.Stop(context.Background())
+
+		assert.ErrorIs(t, err, ErrClusterLeave)
+		assert.ErrorIs(t, err, leaveErr)
+		assert.Equal(t, !abort, called)
+		assert.True(t, flushed)
+		assert.Equal(t, abort, graceful.Config().AbortOnClusterLeaveError)
+
+		if abort {
+			skipped := graceful.LastShutdownReport().Skipped
+			if assert.Len(t, skipped, 1) {
+				assert.Equal(t, SkipReasonAborted, skipped[0].Reason)
+			}
+		}
+	}
+}
+
+func TestGraceful_SetClusterLeaveFuncNonFatal(t *testing.T) {
+	graceful := New()
+	graceful.SetAbortOnClusterLeaveError(true)
+	graceful.SetClusterLeaveFunc(func(ctx context.Context) error {
+		return NonFatal(errors.New("leave err"))
+	})
+
+	var called bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		called = true
+
+		return nil
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.True(t, called)
+}
@@ -0,0 +1,46 @@
+package graceful
+
+import (
+	"sync/atomic"
+)
+
+// SetExitOnComplete set exit on complete value.
+// when it's true, shutdown process is run and Wait returns once all background processes are returned without error,
+// so batch job can exit cleanly without waiting for os signal. Wait returns right away when no background process
+// is registered, and the shutdown entry point is EntryPointComplete. the default value is false.
+func (g *Graceful) SetExitOnComplete(value bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.exitOnComplete = value
+}
+
+// startProcess count background process that is started.
+func (g *Graceful) startProcess() {
+	atomic.AddInt32(&g.runningProcesses, 1)
+}
+
+// finishProcess count background process that is returned, and trigger shutdown process
+// when it's the last one and it's returned without error.
+func (g *Graceful) finishProcess(err error) {
+	if atomic.AddInt32(&g.runningProcesses, -1) == 0 && err == nil {
+		g.checkComplete()
+	}
+}
+
+// checkComplete trigger shutdown process when exit on complete is enabled, Wait is running
+// and no background process is running.
+func (g *Graceful) checkComplete() {
+	g.mutex.Lock()
+
+	if !g.exitOnComplete || g.state != stateWaiting || atomic.LoadInt32(&g.runningProcesses) > 0 {
+		g.mutex.Unlock()
+
+		return
+	}
+
+	g.setEntryPoint(EntryPointComplete)
+	g.mutex.Unlock()
+
+	g.trigger()
+}
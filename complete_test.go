@@ -0,0 +1,92 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetExitOnComplete(t *testing.T) {
+	graceful := New()
+	graceful.SetExitOnComplete(true)
+
+	var shutdownCalled bool
+
+	for i := 0; i < 3; i++ {
+		delay := time.Duration(i) * 10 * time.Millisecond
+
+		graceful.RegisterProcess(func() error {
+			time.Sleep(delay)
+
+			return nil
+		})
+	}
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+
+		return nil
+	})
+
+	assert.Nil(t, graceful.Wait())
+	assert.True(t, shutdownCalled)
+	assert.Equal(t, EntryPointComplete, graceful.ShutdownEntryPoint())
+}
+
+func TestGraceful_SetExitOnCompleteNoProcess(t *testing.T) {
+	graceful := New()
+	graceful.SetExitOnComplete(true)
+
+	assert.Nil(t, graceful.Wait())
+	assert.Equal(t, EntryPointComplete, graceful.ShutdownEntryPoint())
+}
+
+func TestGraceful_SetExitOnCompleteProcessError(t *testing.T) {
+	graceful := New()
+	graceful.SetExitOnComplete(true)
+	graceful.SetRunShutdownOnProcessError(false)
+
+	var shutdownCalled bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+
+		return nil
+	})
+
+	processErr := errors.New("process error")
+
+	graceful.RegisterProcess(func() error {
+		return processErr
+	})
+
+	assert.ErrorIs(t, graceful.Wait(), processErr)
+	assert.False(t, shutdownCalled)
+	assert.Equal(t, EntryPointWait, graceful.ShutdownEntryPoint())
+}
+
+func TestGraceful_SetExitOnCompleteDisabled(t *testing.T) {
+	graceful := New()
+
+	graceful.RegisterProcess(func() error {
+		return nil
+	})
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- graceful.Wait()
+	}()
+
+	select {
+	case <-errChan:
+		t.Fatal("Wait is returned without signal")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	graceful.trigger()
+	assert.Nil(t, <-errChan)
+}
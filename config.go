@@ -0,0 +1,107 @@
+package graceful
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Config snapshot of graceful configuration set using the setters.
+type Config struct {
+	MaxShutdownTime           time.Duration          `json:"max_shutdown_time"`
+	SoftShutdownTimeout       time.Duration          `json:"soft_shutdown_timeout,omitempty"`
+	DrainDelay                time.Duration          `json:"drain_delay,omitempty"`
+	ShutdownPacing            time.Duration          `json:"shutdown_pacing,omitempty"`
+	SlowHookThreshold         float64                `json:"slow_hook_threshold,omitempty"`
+	MaxShutdownProcess        int                    `json:"max_shutdown_process"`
+	MaxShutdownWaves          int                    `json:"max_shutdown_waves"`
+	ShutdownBatchRetry        int                    `json:"shutdown_batch_retry,omitempty"`
+	TelemetryFlushTimeout     time.Duration          `json:"telemetry_flush_timeout"`
+	TerminationGracePeriod    time.Duration          `json:"termination_grace_period,omitempty"`
+	ShutdownPhases            []string               `json:"shutdown_phases,omitempty"`
+	PhaseConcurrency          map[string]int         `json:"phase_concurrency,omitempty"`
+	ShutdownScheduler         bool                   `json:"shutdown_scheduler"`
+	ShutdownLess              bool                   `json:"shutdown_less"`
+	ShutdownLimiter           bool                   `json:"shutdown_limiter"`
+	Signals                   []string               `json:"signals,omitempty"`
+	SignalJitter              time.Duration          `json:"signal_jitter,omitempty"`
+	ForceExitWindow           time.Duration          `json:"force_exit_window,omitempty"`
+	ShutdownVeto              bool                   `json:"shutdown_veto"`
+	ClusterLeave              bool                   `json:"cluster_leave"`
+	AbortOnClusterLeaveError  bool                   `json:"abort_on_cluster_leave_error"`
+	CancelOnError             bool                   `json:"cancel_on_error"`
+	RunShutdownOnProcessError bool                   `json:"run_shutdown_on_process_error"`
+	ReturnCancelCause         bool                   `json:"return_cancel_cause"`
+	ReportAllErrors           bool                   `json:"report_all_errors"`
+	ExitOnComplete            bool                   `json:"exit_on_complete"`
+	ProcessConcurrency        int                    `json:"process_concurrency,omitempty"`
+	LabelGoroutines           bool                   `json:"label_goroutines"`
+	LeakDetection             bool                   `json:"leak_detection"`
+	CoalesceErrorLogs         bool                   `json:"coalesce_error_logs"`
+	WarnDuplicateFuncs        bool                   `json:"warn_duplicate_funcs"`
+	WarnConcurrencySaturation bool                   `json:"warn_concurrency_saturation"`
+	AbandonPolicy             AbandonPolicy          `json:"abandon_policy"`
+	StrictNil                 bool                   `json:"strict_nil"`
+	LogFields                 map[string]interface{} `json:"log_fields,omitempty"`
+}
+
+// Config get snapshot copy of the current configuration, function options like shutdown scheduler
+// and shutdown veto are reported as whether they're set.
+func (g *Graceful) Config() Config {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	config := Config{
+		MaxShutdownTime:           g.maxShutdownTime,
+		SoftShutdownTimeout:       g.softShutdownTimeout,
+		DrainDelay:                g.drainDelay,
+		ShutdownPacing:            g.shutdownPacing,
+		SlowHookThreshold:         g.slowHookThreshold,
+		MaxShutdownProcess:        g.maxShutdownProcess,
+		MaxShutdownWaves:          g.maxShutdownWaves,
+		ShutdownBatchRetry:        g.batchRetry,
+		TelemetryFlushTimeout:     g.telemetryFlushTimeout,
+		TerminationGracePeriod:    g.terminationGracePeriod,
+		ShutdownPhases:            append([]string(nil), g.phases...),
+		ShutdownScheduler:         g.scheduler != nil,
+		ShutdownLess:              g.shutdownLess != nil,
+		ShutdownLimiter:           g.shutdownLimiter != nil,
+		SignalJitter:              g.signalJitter,
+		ForceExitWindow:           g.forceExitWindow,
+		ShutdownVeto:              g.shutdownVeto != nil,
+		ClusterLeave:              g.clusterLeave != nil,
+		AbortOnClusterLeaveError:  g.abortOnLeaveError,
+		CancelOnError:             g.cancelOnError,
+		RunShutdownOnProcessError: g.shutdownOnError,
+		ReturnCancelCause:         g.returnCancelCause,
+		ReportAllErrors:           g.reportAllErrors,
+		ExitOnComplete:            g.exitOnComplete,
+		ProcessConcurrency:        g.processConcurrency,
+		LabelGoroutines:           g.labelGoroutines,
+		LeakDetection:             g.leakDetection,
+		CoalesceErrorLogs:         g.coalesceErrorLogs,
+		WarnDuplicateFuncs:        g.warnDuplicateFuncs,
+		WarnConcurrencySaturation: g.warnSaturation,
+		AbandonPolicy:             g.abandonPolicy,
+		StrictNil:                 atomic.LoadInt32(&strictNil) == 1,
+	}
+
+	if len(g.phaseConcurrency) > 0 {
+		config.PhaseConcurrency = make(map[string]int, len(g.phaseConcurrency))
+		for phase, limit := range g.phaseConcurrency {
+			config.PhaseConcurrency[phase] = limit
+		}
+	}
+
+	for _, sig := range g.signals {
+		config.Signals = append(config.Signals, sig.String())
+	}
+
+	if len(g.logFields) > 0 {
+		config.LogFields = make(map[string]interface{}, len(g.logFields))
+		for key, value := range g.logFields {
+			config.LogFields[key] = value
+		}
+	}
+
+	return config
+}
@@ -0,0 +1,53 @@
+package graceful
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_Config(t *testing.T) {
+	graceful := New(syscall.SIGTERM)
+
+	config := graceful.Config()
+	assert.Equal(t, DefaultMaxShutdownTime, config.MaxShutdownTime)
+	assert.Equal(t, DefaultMaxShutdownProcess, config.MaxShutdownProcess)
+	assert.Equal(t, DefaultMaxShutdownWaves, config.MaxShutdownWaves)
+	assert.Equal(t, []string{syscall.SIGTERM.String()}, config.Signals)
+	assert.False(t, config.CancelOnError)
+	assert.False(t, config.ReportAllErrors)
+	assert.True(t, config.RunShutdownOnProcessError)
+	assert.False(t, config.ShutdownVeto)
+
+	graceful.SetMaxShutdownTime(time.Minute)
+	graceful.SetMaxShutdownProcess(2)
+	graceful.SetCancelOnError(true)
+	graceful.SetReportAllErrors(true)
+	graceful.SetShutdownPhases("http", "storage")
+	graceful.SetPhaseConcurrency("storage", 1)
+	graceful.SetLogFields(map[string]interface{}{"service": "billing"})
+	graceful.SetShutdownVeto(func(sig os.Signal) bool {
+		return true
+	})
+
+	config = graceful.Config()
+	assert.Equal(t, time.Minute, config.MaxShutdownTime)
+	assert.Equal(t, 2, config.MaxShutdownProcess)
+	assert.True(t, config.CancelOnError)
+	assert.True(t, config.ReportAllErrors)
+	assert.Equal(t, []string{"http", "storage"}, config.ShutdownPhases)
+	assert.Equal(t, map[string]int{"storage": 1}, config.PhaseConcurrency)
+	assert.Equal(t, map[string]interface{}{"service": "billing"}, config.LogFields)
+	assert.True(t, config.ShutdownVeto)
+
+	config.PhaseConcurrency["storage"] = 10
+	config.ShutdownPhases[0] = "changed"
+	assert.Equal(t, map[string]int{"storage": 1}, graceful.Config().PhaseConcurrency)
+	assert.Equal(t, []string{"http", "storage"}, graceful.Config().ShutdownPhases)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+}
@@ -0,0 +1,16 @@
+package graceful
+
+import (
+	"context"
+)
+
+// RegisterConnectionDrainer register drain of long-lived connections using tag,
+// e.g. broadcast close frame to websocket connections or cancel their contexts.
+// it's run on ConnectionDrainPhase before any other shutdown process, so http.Server.Shutdown
+// registered as normal shutdown process can drain the remaining requests without waiting them.
+func (g *Graceful) RegisterConnectionDrainer(drain func(ctx context.Context) error, tag string) string {
+	shutdownProcess := newUserShutdown(tag, drain)
+	shutdownProcess.phase = ConnectionDrainPhase
+
+	return g.registerShutdown("RegisterConnectionDrainer", shutdownProcess)
+}
@@ -0,0 +1,23 @@
+package graceful
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterConnectionDrainer(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownPhases("storage")
+
+	var calls callRecorder
+
+	graceful.RegisterShutdownProcessWithPhase(calls.hook("database"), "database", "storage")
+	graceful.RegisterShutdownProcessWithTag(calls.hook("http-server"), "http-server")
+	graceful.RegisterConnectionDrainer(calls.hook("websocket"), "websocket")
+
+	assert.Equal(t, []string{"websocket", "http-server", "database"}, graceful.DryRun(context.Background()))
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, []string{"websocket", "http-server", "database"}, calls.list())
+}
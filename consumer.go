@@ -0,0 +1,67 @@
+package graceful
+
+import (
+	"context"
+	"sync"
+)
+
+// ConsumerDrainer drain message queue consumer that must stop fetching new message on shutdown,
+// but finish processing the in-flight message.
+type ConsumerDrainer struct {
+	gate    *Gate
+	stopped chan struct{}
+	once    sync.Once
+}
+
+// NewConsumerDrainer init consumer drainer that is drained as shutdown process,
+// the shutdown process stop fetching first then wait for all in-flight message is processed.
+func (g *Graceful) NewConsumerDrainer() *ConsumerDrainer {
+	drainer := &ConsumerDrainer{
+		gate:    newGate(),
+		stopped: make(chan struct{}),
+	}
+
+	g.RegisterShutdownProcess(drainer.drain)
+
+	return drainer
+}
+
+// StartFetch check whether consumer can fetch new message, it's false once fetching is stopped.
+func (d *ConsumerDrainer) StartFetch() bool {
+	select {
+	case <-d.stopped:
+		return false
+	default:
+		return true
+	}
+}
+
+// StopFetch stop fetching new message, it's safe to call more than once.
+func (d *ConsumerDrainer) StopFetch() {
+	d.once.Do(func() {
+		close(d.stopped)
+	})
+}
+
+// FetchStopped get channel that is closed once fetching is stopped, e.g. to cancel blocking fetch.
+func (d *ConsumerDrainer) FetchStopped() <-chan struct{} {
+	return d.stopped
+}
+
+// Track track fetched message as in-flight until done is called.
+// message that is already fetched is always tracked even after fetching is stopped.
+func (d *ConsumerDrainer) Track() (done func()) {
+	return d.gate.track()
+}
+
+// Inflight get number of in-flight message.
+func (d *ConsumerDrainer) Inflight() int {
+	return d.gate.inflight()
+}
+
+// drain stop fetching and wait until all in-flight message is processed or ctx is done.
+func (d *ConsumerDrainer) drain(ctx context.Context) error {
+	d.StopFetch()
+
+	return d.gate.drain(ctx)
+}
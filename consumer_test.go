@@ -0,0 +1,77 @@
+package graceful
+
+import (
+	"context"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_NewConsumerDrainer(t *testing.T) {
+	graceful := New()
+	drainer := graceful.NewConsumerDrainer()
+
+	var processed, fetched int32
+
+	graceful.RegisterProcess(func() error {
+		for drainer.StartFetch() {
+			done := drainer.Track()
+			atomic.AddInt32(&fetched, 1)
+
+			go func() {
+				defer done()
+
+				time.Sleep(200 * time.Millisecond)
+				atomic.AddInt32(&processed, 1)
+			}()
+
+			select {
+			case <-time.After(20 * time.Millisecond):
+			case <-drainer.FetchStopped():
+			}
+		}
+
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.False(t, drainer.StartFetch())
+	assert.Equal(t, 0, drainer.Inflight())
+	assert.Greater(t, atomic.LoadInt32(&fetched), int32(0))
+	assert.Equal(t, atomic.LoadInt32(&fetched), atomic.LoadInt32(&processed))
+}
+
+func TestGraceful_NewConsumerDrainerTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+
+	drainer := graceful.NewConsumerDrainer()
+	drainer.Track()
+
+	err := graceful.Stop(context.Background())
+
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Equal(t, 1, drainer.Inflight())
+}
+
+func TestGraceful_NewConsumerDrainerTrackAfterDrain(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+	drainer := graceful.NewConsumerDrainer()
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	done := drainer.Track()
+	assert.Equal(t, 1, drainer.Inflight())
+
+	assert.NotPanics(t, done)
+	assert.Equal(t, 0, drainer.Inflight())
+}
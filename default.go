@@ -7,15 +7,148 @@ import (
 )
 
 const (
-	// defaultMaxShutdownTime default value for max shutdown time.
-	defaultMaxShutdownTime = 10 * time.Second
-	// defaultMaxShutdownProcess default value for max shutdown process.
-	defaultMaxShutdownProcess = 5
+	// DefaultMaxShutdownTime default value for max shutdown time.
+	DefaultMaxShutdownTime = 10 * time.Second
+	// DefaultMaxShutdownProcess default value for max shutdown process.
+	DefaultMaxShutdownProcess = 5
+	// DefaultMaxShutdownWaves default value for max shutdown waves.
+	DefaultMaxShutdownWaves = 10
+	// DefaultTelemetryFlushTimeout default value for telemetry flush timeout.
+	DefaultTelemetryFlushTimeout = 5 * time.Second
+	// ConnectionDrainPhase shutdown phase of connection drainer that is run before any other phase.
+	ConnectionDrainPhase = "connection-drain"
+	// StopPhase shutdown phase of stop function of RegisterStopClose that is run after connection drain phase
+	// and before flush phase.
+	StopPhase = "stop"
+	// FlushPhase shutdown phase of flusher that is run after stop phase and before any other phase.
+	FlushPhase = "flush"
+	// ClosePhase shutdown phase of close function of RegisterStopClose that is run after all other shutdown process
+	// except the reserved phases, e.g. admin server, telemetry flush and log flusher.
+	ClosePhase = "close"
+	// TelemetryFlushPhase shutdown phase of telemetry flush that is run after all other shutdown process except log flusher.
+	TelemetryFlushPhase = "telemetry-flush"
+	// AdminServerPhase shutdown phase of admin server that is run after all other shutdown process
+	// except telemetry flush and log flusher.
+	AdminServerPhase = "admin-server"
+	// LogFlushPhase shutdown phase of log flusher that is run after all other shutdown process.
+	LogFlushPhase = "log-flush"
+)
+
+const (
+	// eventBufferSize buffer size of lifecycle events channel.
+	eventBufferSize = 64
+	// adminReadHeaderTimeout read header timeout of admin server.
+	adminReadHeaderTimeout = 5 * time.Second
+	// adminServerTag tag of admin server shutdown process.
+	adminServerTag = "graceful-admin-server"
+	// clusterLeaveTag tag of cluster leave func shutdown process.
+	clusterLeaveTag = "graceful-cluster-leave"
+	// signalWatcherStopTimeout max time to wait signal watcher goroutine exit when Wait returns.
+	signalWatcherStopTimeout = time.Second
 	// shutdownTag add process tag on shutdown process.
 	shutdownTag = "graceful-shutdown-tag"
+	// workerPoolTag add worker pool tag on job error.
+	workerPoolTag = "graceful-worker-pool-tag"
+	// registerMethodTag add register method name on register error.
+	registerMethodTag = "graceful-register-method"
+	// registeredTagTag add tag that duplicate tag is registered as.
+	registeredTagTag = "graceful-registered-tag"
+	// goroutineLabelKey pprof label key for shutdown process goroutine.
+	goroutineLabelKey = "graceful-hook"
+	// goroutineDeltaTag add goroutine count delta on leak detection.
+	goroutineDeltaTag = "goroutine-delta"
+	// shutdownTagsTag add tags of shutdown process that got the same error on coalesced error log.
+	shutdownTagsTag = "graceful-shutdown-tags"
+	// errorCountTag add number of shutdown process that got the same error on coalesced error log.
+	errorCountTag = "error-count"
+	// durationTag add duration of finished shutdown process.
+	durationTag = "duration"
+	// hookElapsedTag add elapsed time of running shutdown process.
+	hookElapsedTag = "elapsed"
+	// shutdownSkippedTag add number of skipped shutdown process.
+	shutdownSkippedTag = "shutdown-skipped"
+	// shutdownRetryTag add attempt number of shutdown batch retry.
+	shutdownRetryTag = "shutdown-retry"
+	// tickerTag add ticker tag on tick error.
+	tickerTag = "graceful-ticker-tag"
+	// abandonedErrorTag add result of abandoned shutdown process once it's finished.
+	abandonedErrorTag = "abandoned-error"
+	// gracePeriodTag add termination grace period when max shutdown time is aligned.
+	gracePeriodTag = "termination-grace-period"
+	// maxShutdownTimeTag add configured max shutdown time when it's aligned.
+	maxShutdownTimeTag = "max-shutdown-time"
+	// alignedShutdownTimeTag add aligned max shutdown time.
+	alignedShutdownTimeTag = "aligned-max-shutdown-time"
+	// drainDelayTag add configured drain delay when it's clamped.
+	drainDelayTag = "drain-delay"
+	// clampedDrainDelayTag add clamped drain delay.
+	clampedDrainDelayTag = "clamped-drain-delay"
+	// platformTag add current os on skipped os signals.
+	platformTag = "platform"
+	// skippedSignalsTag add os signals that are skipped on the current os.
+	skippedSignalsTag = "skipped-signals"
+	// hookCountTag add number of run shutdown process on concurrency saturation.
+	hookCountTag = "hook-count"
+	// maxShutdownProcessTag add max shutdown process on concurrency saturation.
+	maxShutdownProcessTag = "max-shutdown-process"
+	// blockedTimeTag add total time shutdown process spent waiting for concurrency slot.
+	blockedTimeTag = "blocked-time"
+	// duplicateOfTag add tag of shutdown process that is registered using the same function.
+	duplicateOfTag = "duplicate-of"
+	// signalTag add received os signal.
+	signalTag = "signal"
+	// sinceFirstSignalTag add elapsed time since the first os signal.
+	sinceFirstSignalTag = "since-first-signal"
+	// exitCodeTag add exit code on RunAndExit.
+	exitCodeTag = "exit-code"
 	// shutdownSuccessMessage default message when shutdown success.
 	shutdownSuccessMessage = "shutdown success"
+	// goroutineLeakMessage default message when goroutine count is grew after shutdown.
+	goroutineLeakMessage = "goroutine count grew after shutdown"
+	// finalizerPanicMessage default message when finalizer is panic.
+	finalizerPanicMessage = "finalizer panic recovered"
+	// slowHookMessage default message when shutdown process is still running after slow hook threshold.
+	slowHookMessage = "shutdown process is close to its deadline"
+	// contextDoneMessage default message when parent context is already done on init.
+	contextDoneMessage = "context is already done, shutdown process will run right away"
+	// shutdownProfileMessage default message when cpu profile of shutdown process is failed to start.
+	shutdownProfileMessage = "failed to start shutdown cpu profile"
+	// maxShutdownWavesMessage default message when shutdown process is skipped due to max shutdown waves.
+	maxShutdownWavesMessage = "max shutdown waves reached, skipping shutdown process"
+	// duplicateFuncMessage default message when shutdown process function is already registered.
+	duplicateFuncMessage = "shutdown process function is already registered"
+	// abandonedHookMessage default message when shutdown process is abandoned with detach policy.
+	abandonedHookMessage = "shutdown process is abandoned, detaching it"
+	// abandonedHookFinishedMessage default message when detached shutdown process is finished.
+	abandonedHookFinishedMessage = "abandoned shutdown process is finished"
+	// gracePeriodMessage default message when shutdown could outlast the termination grace period.
+	gracePeriodMessage = "shutdown could outlast termination grace period, aligning max shutdown time"
+	// gracePeriodEnvMessage default message when termination grace period env var is invalid.
+	gracePeriodEnvMessage = "invalid termination grace period env var"
+	// forceExitMessage default message when second os signal force the process to exit.
+	forceExitMessage = "second signal received within force exit window, exiting without waiting for shutdown"
+	// drainDelayMessage default message when drain delay leaves the shutdown process too little of max shutdown time.
+	drainDelayMessage = "drain delay exceeds half of max shutdown time, clamping it to leave time for shutdown process"
+	// unsupportedSignalMessage default message when os signals can't be handled on the current os.
+	unsupportedSignalMessage = "skipping os signals that are not supported on this platform"
+	// noSupportedSignalMessage default message when all os signals are skipped and the default signals are used.
+	noSupportedSignalMessage = "no os signal is supported on this platform, falling back to the default signals"
+	// concurrencySaturatedMessage default message when shutdown process is effectively serialized by max shutdown process.
+	concurrencySaturatedMessage = "shutdown process queued on concurrency limit, consider raising max shutdown process"
+	// shutdownLimiterMessage default message when shutdown limiter is failed to acquire.
+	shutdownLimiterMessage = "failed to acquire shutdown limiter, skipping shutdown process"
+	// exitMessage default message when RunAndExit is exiting after clean shutdown.
+	exitMessage = "application exited"
+	// exitErrorMessage default message when RunAndExit is exiting with error.
+	exitErrorMessage = "application exited with an error"
+	// shutdownRetryMessage default message when failed shutdown process is retried.
+	shutdownRetryMessage = "retrying failed shutdown process"
 )
 
 // defaultSignals default os signal that will be handled.
 var defaultSignals = []os.Signal{os.Interrupt, syscall.SIGINT, syscall.SIGTERM, syscall.SIGHUP}
+
+// DefaultSignals get copy of default os signal that will be handled.
+func DefaultSignals() []os.Signal {
+	return append([]os.Signal(nil), defaultSignals...)
+}
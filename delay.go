@@ -0,0 +1,50 @@
+package graceful
+
+import (
+	"context"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SetDrainDelay set drain delay value.
+// shutdown process is started only after the delay once shutdown is triggered, e.g. to let load balancer
+// deregister the app after readiness is failed while it's still serving. the delay is counted in max shutdown time,
+// so it's clamped to half of max shutdown time with a warning to leave the shutdown process enough time.
+// 0 or less disable it, which is the default value.
+func (g *Graceful) SetDrainDelay(delay time.Duration) {
+	g.drainDelay = delay
+}
+
+// effectiveDrainDelay get drain delay clamped to half of max shutdown time.
+func (g *Graceful) effectiveDrainDelay() time.Duration {
+	if g.drainDelay <= 0 {
+		return 0
+	}
+
+	limit := g.maxShutdownTime / 2
+	if g.drainDelay <= limit {
+		return g.drainDelay
+	}
+
+	log.Warn().Fields(g.logFields).
+		Dur(drainDelayTag, g.drainDelay).
+		Dur(maxShutdownTimeTag, g.maxShutdownTime).
+		Dur(clampedDrainDelayTag, limit).
+		Msg(drainDelayMessage)
+
+	return limit
+}
+
+// waitDrainDelay wait for the effective drain delay or ctx is done.
+func (g *Graceful) waitDrainDelay(ctx context.Context) {
+	delay := g.effectiveDrainDelay()
+	if delay <= 0 {
+		return
+	}
+
+	select {
+	case <-g.clock.After(delay):
+	case <-ctx.Done():
+	}
+}
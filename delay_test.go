@@ -0,0 +1,65 @@
+package graceful
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetDrainDelay(t *testing.T) {
+	graceful := New()
+	graceful.SetDrainDelay(100 * time.Millisecond)
+
+	var startedAfter time.Duration
+
+	triggeredAt := time.Now()
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		startedAfter = time.Since(triggeredAt)
+
+		return nil
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.GreaterOrEqual(t, startedAfter, 100*time.Millisecond)
+	assert.Equal(t, 100*time.Millisecond, graceful.Config().DrainDelay)
+}
+
+func TestGraceful_SetDrainDelayNoShutdownProcess(t *testing.T) {
+	graceful := New()
+	graceful.SetDrainDelay(100 * time.Millisecond)
+
+	startedAt := time.Now()
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.GreaterOrEqual(t, time.Since(startedAt), 100*time.Millisecond)
+}
+
+func TestGraceful_SetDrainDelayExceedMaxShutdownTime(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetMaxShutdownTime(200 * time.Millisecond)
+	graceful.SetDrainDelay(time.Second)
+
+	assert.Equal(t, 100*time.Millisecond, graceful.effectiveDrainDelay())
+	assert.Equal(t, 1, strings.Count(logs.String(), drainDelayMessage))
+
+	var called bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		called = true
+
+		return nil
+	})
+
+	startedAt := time.Now()
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.True(t, called)
+	assert.Less(t, time.Since(startedAt), 200*time.Millisecond)
+	assert.Equal(t, 2, strings.Count(logs.String(), drainDelayMessage))
+}
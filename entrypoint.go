@@ -0,0 +1,29 @@
+package graceful
+
+const (
+	// EntryPointWait shutdown process is driven by Wait, e.g. on os signal or parent context cancellation.
+	EntryPointWait = "wait"
+	// EntryPointStop shutdown process is driven by Stop.
+	EntryPointStop = "stop"
+	// EntryPointDrainNow shutdown process is driven by DrainNow.
+	EntryPointDrainNow = "drain-now"
+	// EntryPointComplete shutdown process is driven by all background processes are returned, see SetExitOnComplete.
+	EntryPointComplete = "complete"
+)
+
+// ShutdownEntryPoint get entry point that drive the shutdown process,
+// empty when no entry point is called yet.
+func (g *Graceful) ShutdownEntryPoint() string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.entryPoint
+}
+
+// setEntryPoint record entry point when shutdown process is not started yet, must be called with mutex locked.
+// programmatic entry point override Wait, since it's the one that drive the shutdown process.
+func (g *Graceful) setEntryPoint(entryPoint string) {
+	if g.state < stateShuttingDown {
+		g.entryPoint = entryPoint
+	}
+}
@@ -0,0 +1,147 @@
+package graceful
+
+import (
+	"errors"
+)
+
+var (
+	// ErrShutdownTimeout shutdown process is not finished within max shutdown time.
+	ErrShutdownTimeout = errors.New("graceful: shutdown timeout")
+	// ErrNilProcess register method got nil process.
+	ErrNilProcess = errors.New("graceful: nil process")
+	// ErrAlreadyWaiting Wait is called more than once.
+	ErrAlreadyWaiting = errors.New("graceful: already waiting")
+	// ErrRegisterAfterShutdown register method is called after shutdown process is done.
+	ErrRegisterAfterShutdown = errors.New("graceful: register after shutdown")
+	// ErrStopRequested background process can return it to trigger shutdown process
+	// without being treated as failure, so Wait returns nil.
+	ErrStopRequested = errors.New("graceful: stop requested")
+	// ErrDuplicateTag shutdown process or background process is registered using tag that is already registered,
+	// it's logged and the process is registered using the tag suffixed with its occurrence.
+	ErrDuplicateTag = errors.New("graceful: duplicate tag")
+	// ErrContextDone parent context is already done when graceful is created.
+	ErrContextDone = errors.New("graceful: context already done")
+	// ErrShutdownStarted partial shutdown is called after full shutdown process is started.
+	ErrShutdownStarted = errors.New("graceful: shutdown already started")
+	// ErrNotReady declared process is not marked as ready before WaitReady context is done.
+	ErrNotReady = errors.New("graceful: not ready")
+	// ErrPreflight preflight check is failed, so Wait is returned without starting background processes.
+	ErrPreflight = errors.New("graceful: preflight failed")
+	// ErrInvalidInterval register method got interval that is not positive.
+	ErrInvalidInterval = errors.New("graceful: invalid interval")
+	// ErrHookCancelled shutdown process is cancelled using CancelHook.
+	ErrHookCancelled = errors.New("graceful: hook cancelled")
+	// ErrShutdownPanic shutdown process in shutdown group is panic, the panic is recovered as error.
+	ErrShutdownPanic = errors.New("graceful: shutdown process panic")
+	// ErrHookTimeout shutdown process returned deadline exceeded after the deadline imposed on it is exceeded,
+	// e.g. soft shutdown timeout, it still match context.DeadlineExceeded.
+	ErrHookTimeout = errors.New("graceful: hook timeout")
+	// ErrForceExit second os signal is received within force exit window, so the process is exited without waiting
+	// for shutdown process, it's passed to SetExitCode mapping to get the exit code.
+	ErrForceExit = errors.New("graceful: force exit")
+	// ErrProcessRunning process concurrency is set after background process is registered.
+	ErrProcessRunning = errors.New("graceful: background process is running")
+	// ErrClusterLeave cluster leave func is failed, see SetClusterLeaveFunc.
+	ErrClusterLeave = errors.New("graceful: cluster leave failed")
+)
+
+// sentinelError error that match sentinel on errors.Is while keeping the original error unwrapped.
+type sentinelError struct {
+	sentinel error
+	err      error
+}
+
+// wrapSentinel wrap err with sentinel, so errors.Is works for both of them.
+func wrapSentinel(sentinel, err error) error {
+	return &sentinelError{
+		sentinel: sentinel,
+		err:      err,
+	}
+}
+
+// Error get error message of sentinel and the original error.
+func (e *sentinelError) Error() string {
+	return e.sentinel.Error() + ": " + e.err.Error()
+}
+
+// Is report whether target is the sentinel.
+func (e *sentinelError) Is(target error) bool {
+	return target == e.sentinel
+}
+
+// Unwrap get the original error.
+func (e *sentinelError) Unwrap() error {
+	return e.err
+}
+
+// nonFatalError error that is never treated as cancellation trigger.
+type nonFatalError struct {
+	err error
+}
+
+// NonFatal wrap err so it's recorded and logged but never cancel other shutdown process,
+// even when cancel on error is enabled. nil err will return nil.
+func NonFatal(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &nonFatalError{err: err}
+}
+
+// Error get the original error message.
+func (e *nonFatalError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap get the original error.
+func (e *nonFatalError) Unwrap() error {
+	return e.err
+}
+
+// fatalError error that is always treated as cancellation trigger.
+type fatalError struct {
+	err error
+}
+
+// Fatal wrap err so it always cancel other shutdown process,
+// even when cancel on error is disabled. nil err will return nil.
+func Fatal(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return &fatalError{err: err}
+}
+
+// Error get the original error message.
+func (e *fatalError) Error() string {
+	return e.err.Error()
+}
+
+// Unwrap get the original error.
+func (e *fatalError) Unwrap() error {
+	return e.err
+}
+
+// isCancellationError check whether shutdown process error should cancel other shutdown process.
+func isCancellationError(err error, cancelOnError bool) bool {
+	if err == nil {
+		return false
+	}
+
+	var (
+		nonFatal *nonFatalError
+		fatal    *fatalError
+	)
+
+	if errors.As(err, &fatal) {
+		return true
+	}
+
+	if errors.As(err, &nonFatal) {
+		return false
+	}
+
+	return cancelOnError
+}
@@ -0,0 +1,52 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_NonFatal(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+
+	errNonFatal := errors.New("non fatal")
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return NonFatal(errNonFatal)
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.ErrorIs(t, graceful.LastShutdownReport().Hooks[0].Err, errNonFatal)
+	assert.Nil(t, NonFatal(nil))
+}
+
+func TestGraceful_Fatal(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(false)
+
+	errFatal := errors.New("fatal")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return Fatal(errFatal)
+	}, "database")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.ErrorIs(t, err, errFatal)
+	assert.EqualError(t, err, "database: fatal")
+	assert.Nil(t, Fatal(nil))
+}
@@ -0,0 +1,29 @@
+package graceful
+
+import (
+	"context"
+)
+
+// gracefulKey context key of graceful that run the shutdown process.
+type gracefulKey struct{}
+
+// withGraceful add graceful to shutdown process context, so shutdown process can escalate using CancelProcesses.
+func withGraceful(ctx context.Context, g *Graceful) context.Context {
+	return context.WithValue(ctx, gracefulKey{}, g)
+}
+
+// CancelProcesses cancel context of still running background processes from shutdown process using its ctx,
+// e.g. to kill in-flight work the shutdown process depends on. it's the same as os signal or Stop, so it also
+// trigger the full shutdown process when Wait is running, e.g. when it's called from ShutdownTags or DrainNow.
+// background processes are already cancelled when the shutdown process is driven by Wait, and process without
+// context can't be cancelled. it returns false when ctx is not the context of shutdown process.
+func CancelProcesses(ctx context.Context) bool {
+	g, ok := ctx.Value(gracefulKey{}).(*Graceful)
+	if !ok {
+		return false
+	}
+
+	g.trigger()
+
+	return true
+}
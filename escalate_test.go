@@ -0,0 +1,51 @@
+package graceful
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCancelProcesses(t *testing.T) {
+	graceful := New()
+
+	var (
+		processCancelled = make(chan struct{})
+		cancelled        bool
+	)
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		close(processCancelled)
+
+		return nil
+	})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		assert.True(t, CancelProcesses(ctx))
+
+		select {
+		case <-processCancelled:
+			cancelled = true
+		case <-time.After(time.Second):
+		}
+
+		return nil
+	}, "escalate")
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- graceful.Wait()
+	}()
+
+	assert.Nil(t, graceful.ShutdownTags(context.Background(), "escalate"))
+	assert.True(t, cancelled)
+	assert.Nil(t, <-errChan)
+}
+
+func TestCancelProcessesOutsideShutdown(t *testing.T) {
+	assert.False(t, CancelProcesses(context.Background()))
+}
@@ -0,0 +1,76 @@
+package graceful
+
+import (
+	"os"
+	"time"
+)
+
+// EventType type of lifecycle event.
+type EventType string
+
+const (
+	// EventStarted Wait is started.
+	EventStarted EventType = "started"
+	// EventSignalReceived os signal is received, it's sent for every received signal.
+	EventSignalReceived EventType = "signal-received"
+	// EventShutdownBegan shutdown process is started.
+	EventShutdownBegan EventType = "shutdown-began"
+	// EventHookStarted single shutdown process is started.
+	EventHookStarted EventType = "hook-started"
+	// EventHookFinished single shutdown process is finished.
+	EventHookFinished EventType = "hook-finished"
+	// EventShutdownComplete lifecycle is done, it's the last event before the channel is closed.
+	EventShutdownComplete EventType = "shutdown-complete"
+)
+
+// Event lifecycle event of graceful.
+type Event struct {
+	Type EventType
+	Time time.Time
+	// Signal received os signal on EventSignalReceived.
+	Signal os.Signal
+	// Tag shutdown process tag on EventHookStarted and EventHookFinished.
+	Tag string
+	// Err shutdown process error on EventHookFinished and final result on EventShutdownComplete.
+	Err error
+}
+
+// Events get channel of lifecycle events, the same channel is returned for every call.
+// the channel is buffered up to 64 events and new events are dropped when the buffer is full,
+// so slow consumer never blocks the shutdown process. it's closed after EventShutdownComplete.
+func (g *Graceful) Events() <-chan Event {
+	return g.events
+}
+
+// emit send lifecycle event without blocking, the event is dropped when the buffer is full.
+func (g *Graceful) emit(event Event) {
+	event.Time = time.Now()
+
+	g.eventMutex.Lock()
+	defer g.eventMutex.Unlock()
+
+	if g.eventsClosed {
+		return
+	}
+
+	select {
+	case g.events <- event:
+	default:
+	}
+}
+
+// emitSignal send EventSignalReceived for received os signal.
+func (g *Graceful) emitSignal(sig os.Signal) {
+	g.emit(Event{Type: EventSignalReceived, Signal: sig})
+}
+
+// closeEvents send EventShutdownComplete with the final result and close the events channel.
+func (g *Graceful) closeEvents(err error) {
+	g.emit(Event{Type: EventShutdownComplete, Err: err})
+
+	g.eventMutex.Lock()
+	defer g.eventMutex.Unlock()
+
+	g.eventsClosed = true
+	close(g.events)
+}
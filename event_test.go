@@ -0,0 +1,73 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_Events(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+
+	expectedErr := errors.New("err")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return expectedErr
+	}, "database")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+	assert.ErrorIs(t, err, expectedErr)
+
+	var events []Event
+	for event := range graceful.Events() {
+		assert.False(t, event.Time.IsZero())
+
+		events = append(events, event)
+	}
+
+	types := make([]EventType, 0, len(events))
+	for _, event := range events {
+		types = append(types, event.Type)
+	}
+
+	assert.Equal(t, []EventType{
+		EventStarted,
+		EventSignalReceived,
+		EventShutdownBegan,
+		EventHookStarted,
+		EventHookFinished,
+		EventShutdownComplete,
+	}, types)
+	assert.Equal(t, syscall.SIGTERM, events[1].Signal)
+	assert.Equal(t, "database", events[3].Tag)
+	assert.Equal(t, "database", events[4].Tag)
+	assert.ErrorIs(t, events[4].Err, expectedErr)
+	assert.Equal(t, err, events[5].Err)
+}
+
+func TestGraceful_EventsDropped(t *testing.T) {
+	graceful := New()
+
+	for i := 0; i < eventBufferSize; i++ {
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			return nil
+		})
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	var count int
+	for range graceful.Events() {
+		count++
+	}
+
+	assert.Equal(t, eventBufferSize, count)
+}
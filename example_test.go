@@ -0,0 +1,35 @@
+package graceful_test
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+)
+
+func ExampleRun() {
+	err := graceful.Run(
+		[]func(context.Context) error{
+			func(ctx context.Context) error {
+				fmt.Println("processing batch")
+
+				return graceful.ErrStopRequested
+			},
+		},
+		[]func(context.Context) error{
+			func(ctx context.Context) error {
+				fmt.Println("closing resources")
+
+				return nil
+			},
+		},
+		graceful.WithMaxShutdownTime(5*time.Second),
+	)
+
+	fmt.Println(err)
+	// Output:
+	// processing batch
+	// closing resources
+	// <nil>
+}
@@ -0,0 +1,54 @@
+package graceful
+
+import (
+	"os"
+
+	"github.com/rs/zerolog/log"
+)
+
+// osExit exit the process, it's replaced on test.
+var osExit = os.Exit
+
+// DefaultExitCode default exit code mapping of Wait result, 0 on nil error and 1 otherwise.
+func DefaultExitCode(err error) int {
+	if err == nil {
+		return 0
+	}
+
+	return 1
+}
+
+// SetExitCode set exit code mapping of Wait result that is used by RunAndExit,
+// nil mapping will reset it to DefaultExitCode.
+func (g *Graceful) SetExitCode(mapping func(err error) int) {
+	if mapping == nil {
+		mapping = DefaultExitCode
+	}
+
+	g.exitCode = mapping
+}
+
+// SetExitFlush set flush that is called by RunAndExit right before os.Exit,
+// e.g. to flush buffered logger or telemetry exporter, since os.Exit doesn't run deferred functions.
+func (g *Graceful) SetExitFlush(flush func()) {
+	g.exitFlush = flush
+}
+
+// RunAndExit wait until all processes are done, log the final error, call the exit flush,
+// then exit the process using exit code from SetExitCode mapping.
+func RunAndExit(g *Graceful) {
+	err := g.Wait()
+	code := g.exitCode(err)
+
+	if err != nil {
+		log.Error().Err(err).Int(exitCodeTag, code).Msg(exitErrorMessage)
+	} else {
+		log.Info().Int(exitCodeTag, code).Msg(exitMessage)
+	}
+
+	if g.exitFlush != nil {
+		g.exitFlush()
+	}
+
+	osExit(code)
+}
@@ -0,0 +1,61 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunAndExit(t *testing.T) {
+	var (
+		codes   []int
+		flushed int
+	)
+
+	osExit = func(code int) {
+		codes = append(codes, code)
+	}
+
+	t.Cleanup(func() {
+		osExit = os.Exit
+	})
+
+	clean := NewFromContext(canceledContext())
+	clean.SetExitFlush(func() {
+		flushed++
+	})
+
+	RunAndExit(clean)
+
+	errFailed := errors.New("failed")
+
+	failed := NewFromContext(canceledContext())
+	failed.SetReportAllErrors(true)
+	failed.SetExitCode(func(err error) int {
+		if errors.Is(err, errFailed) {
+			return 3
+		}
+
+		return DefaultExitCode(err)
+	})
+	failed.RegisterShutdownProcess(func(ctx context.Context) error {
+		return errFailed
+	})
+
+	RunAndExit(failed)
+
+	assert.Equal(t, []int{0, 3}, codes)
+	assert.Equal(t, 1, flushed)
+	assert.Equal(t, 1, DefaultExitCode(errFailed))
+}
+
+// canceledContext get context that is already cancelled, so Wait run the shutdown process right away.
+func canceledContext() context.Context {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	return ctx
+}
@@ -0,0 +1,49 @@
+// Package fasthttp integrate graceful with fasthttp server.
+// it's a separate module, so the fasthttp dependency is not required by the graceful module.
+package fasthttp
+
+import (
+	"context"
+	"net"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/valyala/fasthttp"
+)
+
+// RegisterServer register server that serve listener as background process, and shutdown process using tag
+// that call server Shutdown. Shutdown has no context, so it's run on its own goroutine and the shutdown process
+// returns the context error when the shutdown context is done first, while Shutdown keeps draining on background.
+// the listener is closed without serving when the shutdown process can't be registered, e.g. after shutdown is done.
+func RegisterServer(g *graceful.Graceful, server *fasthttp.Server, listener net.Listener, tag string) string {
+	id := g.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return shutdown(ctx, server)
+	}, tag)
+
+	if id == "" {
+		_ = listener.Close()
+
+		return ""
+	}
+
+	g.RegisterProcess(func() error {
+		return server.Serve(listener)
+	})
+
+	return id
+}
+
+// shutdown call server Shutdown and wait until it's done or ctx is done.
+func shutdown(ctx context.Context, server *fasthttp.Server) error {
+	done := make(chan error, 1)
+
+	go func() {
+		done <- server.Shutdown()
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,91 @@
+package fasthttp
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/stretchr/testify/assert"
+	"github.com/valyala/fasthttp"
+)
+
+func listen(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return listener
+}
+
+func TestRegisterServer(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+
+	listener := listen(t)
+	server := &fasthttp.Server{
+		Handler: func(ctx *fasthttp.RequestCtx) {
+			ctx.SetBodyString("ok")
+		},
+	}
+
+	assert.NotEmpty(t, RegisterServer(g, server, listener, "http-server"))
+
+	status, body, err := fasthttp.Get(nil, "http://"+listener.Addr().String())
+	assert.Nil(t, err)
+	assert.Equal(t, fasthttp.StatusOK, status)
+	assert.Equal(t, "ok", string(body))
+
+	assert.Nil(t, g.Stop(context.Background()))
+
+	_, _, err = fasthttp.Get(nil, "http://"+listener.Addr().String())
+	assert.NotNil(t, err)
+}
+
+func TestRegisterServerTimeout(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+	g.SetMaxShutdownTime(100 * time.Millisecond)
+
+	var (
+		listener = listen(t)
+		started  = make(chan struct{})
+		release  = make(chan struct{})
+	)
+
+	server := &fasthttp.Server{
+		Handler: func(ctx *fasthttp.RequestCtx) {
+			close(started)
+			<-release
+		},
+	}
+
+	t.Cleanup(func() {
+		close(release)
+	})
+
+	RegisterServer(g, server, listener, "http-server")
+
+	go func() {
+		_, _, _ = fasthttp.Get(nil, "http://"+listener.Addr().String())
+	}()
+
+	<-started
+
+	startedAt := time.Now()
+
+	assert.ErrorIs(t, g.Stop(context.Background()), graceful.ErrShutdownTimeout)
+	assert.Less(t, time.Since(startedAt), time.Second)
+}
+
+func TestRegisterServerAfterShutdown(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+	assert.Nil(t, g.Stop(context.Background()))
+
+	listener := listen(t)
+
+	assert.Empty(t, RegisterServer(g, &fasthttp.Server{}, listener, "http-server"))
+
+	_, err := listener.Accept()
+	assert.NotNil(t, err)
+}
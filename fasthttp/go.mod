@@ -0,0 +1,25 @@
+module github.com/erry-az/go-graceful/fasthttp
+
+go 1.20
+
+require (
+	github.com/erry-az/go-graceful v0.0.0-00010101000000-000000000000
+	github.com/stretchr/testify v1.8.2
+	github.com/valyala/fasthttp v1.44.0
+)
+
+require (
+	github.com/andybalholm/brotli v1.0.4 // indirect
+	github.com/davecgh/go-spew v1.1.1 // indirect
+	github.com/klauspost/compress v1.15.9 // indirect
+	github.com/mattn/go-colorable v0.1.12 // indirect
+	github.com/mattn/go-isatty v0.0.14 // indirect
+	github.com/pmezard/go-difflib v1.0.0 // indirect
+	github.com/rs/zerolog v1.29.0 // indirect
+	github.com/valyala/bytebufferpool v1.0.0 // indirect
+	golang.org/x/sync v0.1.0 // indirect
+	golang.org/x/sys v0.7.0 // indirect
+	gopkg.in/yaml.v3 v3.0.1 // indirect
+)
+
+replace github.com/erry-az/go-graceful => ../
@@ -0,0 +1,48 @@
+package graceful
+
+import (
+	"fmt"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RegisterFinalizer register finalizer that is run synchronously in registration order after all shutdown process,
+// it's run outside max shutdown time and even when shutdown process is timed out, aborted or skipped by failed preflight,
+// so it's the place for the very last cleanup like closing the logger. finalizer can't return error and can't be cancelled.
+func (g *Graceful) RegisterFinalizer(finalizer func()) {
+	if finalizer == nil {
+		checkNilProcess("RegisterFinalizer")
+
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.state == stateDone {
+		logRegisterError("RegisterFinalizer", ErrRegisterAfterShutdown)
+
+		return
+	}
+
+	g.finalizers = append(g.finalizers, finalizer)
+}
+
+// runFinalizers run all finalizers in registration order, panic on finalizer is recovered and logged.
+func (g *Graceful) runFinalizers() {
+	g.mutex.Lock()
+	finalizers := append([]func(){}, g.finalizers...)
+	g.mutex.Unlock()
+
+	for _, finalizer := range finalizers {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					log.Error().Err(fmt.Errorf("%v", r)).Msg(finalizerPanicMessage)
+				}
+			}()
+
+			finalizer()
+		}()
+	}
+}
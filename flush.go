@@ -0,0 +1,106 @@
+package graceful
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// Flusher buffered writer that can be flushed, e.g. bufio.Writer.
+type Flusher interface {
+	Flush() error
+}
+
+// RegisterFlusher register shutdown process using tag that call Flush of buffered writer on FlushPhase,
+// which is run after ConnectionDrainPhase and StopPhase and before any other phase, so it's run before the Close of the
+// underlying file or connection that is registered as normal shutdown process. flush error is wrapped with the tag.
+func (g *Graceful) RegisterFlusher(flusher Flusher, tag string) string {
+	if flusher == nil {
+		return g.registerShutdown("RegisterFlusher", newShutdown(tag, nil))
+	}
+
+	shutdownProcess := newShutdown(tag, func(ctx context.Context) error {
+		if err := flusher.Flush(); err != nil {
+			return fmt.Errorf("flush: %w", err)
+		}
+
+		return nil
+	})
+	shutdownProcess.phase = FlushPhase
+
+	return g.registerShutdown("RegisterFlusher", shutdownProcess)
+}
+
+// RegisterLogFlusher register flush of buffered log handler that is run on LogFlushPhase after all other shutdown process,
+// including the ones registered by another shutdown process and the ones that are aborted,
+// so log lines from the other shutdown process are captured before the buffer is flushed.
+// unlike finalizer, it's bounded by max shutdown time and its error is returned like other shutdown process.
+func (g *Graceful) RegisterLogFlusher(flush func(ctx context.Context) error) string {
+	shutdownProcess := newUserShutdown("", flush)
+	shutdownProcess.phase = LogFlushPhase
+
+	return g.registerShutdown("RegisterLogFlusher", shutdownProcess)
+}
+
+// RegisterTelemetryFlush register flush of metrics or traces exporter that is run on TelemetryFlushPhase
+// after all other shutdown process and before log flusher, so it captures the final metrics of them.
+// it's run even when the other shutdown process are aborted, using its own telemetry flush timeout
+// instead of the remaining max shutdown time, and its error is returned like other shutdown process.
+func (g *Graceful) RegisterTelemetryFlush(flush func(ctx context.Context) error) string {
+	shutdownProcess := newUserShutdown("", flush)
+	shutdownProcess.phase = TelemetryFlushPhase
+
+	return g.registerShutdown("RegisterTelemetryFlush", shutdownProcess)
+}
+
+// SetTelemetryFlushTimeout set telemetry flush timeout value.
+// the timeout is started when the telemetry flush is started, 0 or less will reset it to default.
+func (g *Graceful) SetTelemetryFlushTimeout(duration time.Duration) {
+	if duration <= 0 {
+		g.telemetryFlushTimeout = DefaultTelemetryFlushTimeout
+
+		return
+	}
+
+	g.telemetryFlushTimeout = duration
+}
+
+// flushTelemetry run telemetry flush using telemetry flush timeout.
+func (g *Graceful) flushTelemetry(flushers []shutdown, run *shutdownRun) error {
+	if len(flushers) == 0 {
+		return nil
+	}
+
+	ctx, cancel := g.clock.WithTimeout(context.Background(), g.telemetryFlushTimeout)
+	defer cancel()
+
+	var err error
+
+	for _, batch := range g.planShutdownPhases(flushers) {
+		if flushErr := g.runShutdownBatch(ctx, batch, run); err == nil {
+			err = flushErr
+		}
+	}
+
+	return err
+}
+
+// reservedPhases shutdown phases that are run in this order after all other shutdown process,
+// even when the other shutdown process are aborted.
+var reservedPhases = []string{AdminServerPhase, TelemetryFlushPhase, LogFlushPhase}
+
+// splitReserved split shutdown process on reserved phases from other shutdown process by the phase,
+// so they can be run after all of them.
+func splitReserved(shutdowns []shutdown) (others []shutdown, reserved map[string][]shutdown) {
+	reserved = make(map[string][]shutdown)
+
+	for _, s := range shutdowns {
+		if containsString(reservedPhases, s.phase) {
+			reserved[s.phase] = append(reserved[s.phase], s)
+		} else {
+			others = append(others, s)
+		}
+	}
+
+	return others, reserved
+}
@@ -0,0 +1,159 @@
+package graceful
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterLogFlusher(t *testing.T) {
+	graceful := New()
+
+	var calls callRecorder
+
+	graceful.RegisterLogFlusher(func(ctx context.Context) error {
+		calls.record("flush")
+		return nil
+	})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		calls.record("http-server")
+
+		graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+			calls.record("session")
+			return nil
+		}, "session")
+
+		return nil
+	}, "http-server")
+
+	graceful.RegisterShutdownProcessWithPhase(func(ctx context.Context) error {
+		calls.record("database")
+		return nil
+	}, "database", "storage")
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, []string{"http-server", "database", "session", "flush"}, calls.list())
+}
+
+func TestGraceful_RegisterLogFlusherAborted(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+
+	var flushed bool
+
+	errDatabase := errors.New("database")
+
+	graceful.RegisterLogFlusher(func(ctx context.Context) error {
+		flushed = true
+		return nil
+	})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errDatabase
+	}, "database")
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), errDatabase)
+	assert.True(t, flushed)
+}
+
+func TestGraceful_RegisterTelemetryFlush(t *testing.T) {
+	graceful := New()
+
+	var calls callRecorder
+
+	graceful.RegisterLogFlusher(func(ctx context.Context) error {
+		calls.record("log")
+		return nil
+	})
+
+	graceful.RegisterTelemetryFlush(func(ctx context.Context) error {
+		calls.record("telemetry")
+		return nil
+	})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		calls.record("http-server")
+		return nil
+	}, "http-server")
+
+	graceful.RegisterFinalizer(func() {
+		calls.record("finalizer")
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, []string{"http-server", "telemetry", "log", "finalizer"}, calls.list())
+}
+
+func TestGraceful_SetTelemetryFlushTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(50 * time.Millisecond)
+	graceful.SetTelemetryFlushTimeout(time.Second)
+
+	var deadline time.Duration
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	}, "stuck")
+
+	graceful.RegisterTelemetryFlush(func(ctx context.Context) error {
+		if d, ok := ctx.Deadline(); ok {
+			deadline = time.Until(d)
+		}
+
+		return nil
+	})
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), ErrShutdownTimeout)
+	assert.Greater(t, deadline, 500*time.Millisecond)
+}
+
+type failedFlusher struct {
+	err error
+}
+
+func (f failedFlusher) Flush() error {
+	return f.err
+}
+
+func TestGraceful_RegisterFlusher(t *testing.T) {
+	graceful := New()
+
+	var (
+		output bytes.Buffer
+		closed string
+	)
+
+	writer := bufio.NewWriter(&output)
+	_, _ = writer.WriteString("buffered line")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		closed = output.String()
+
+		return nil
+	}, "close-file")
+
+	assert.NotEmpty(t, graceful.RegisterFlusher(writer, "file-writer"))
+	assert.Empty(t, graceful.RegisterFlusher(nil, "nil-writer"))
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, "buffered line", closed)
+}
+
+func TestGraceful_RegisterFlusherError(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+
+	errFlush := errors.New("short write")
+	graceful.RegisterFlusher(failedFlusher{err: errFlush}, "sink")
+
+	err := graceful.Stop(context.Background())
+	assert.ErrorIs(t, err, errFlush)
+	assert.EqualError(t, err, "sink: flush: short write")
+}
@@ -0,0 +1,47 @@
+package graceful
+
+import (
+	"os"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SetForceExitWindow set force exit window value.
+// when another os signal is received within the window since the first accepted os signal,
+// the process is exited right away without waiting for shutdown process, using exit code from SetExitCode mapping
+// of ErrForceExit and calling the exit flush first. a later os signal is only counted, so an operator coming back
+// later doesn't hard-kill the app by surprise. 0 or less disable force exit, which is the default value.
+func (g *Graceful) SetForceExitWindow(window time.Duration) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.forceExitWindow = window
+}
+
+// forceExit exit the process when os signal is received within force exit window since the first accepted os signal.
+func (g *Graceful) forceExit(sig os.Signal, sinceFirst time.Duration) {
+	g.mutex.Lock()
+	window := g.forceExitWindow
+	g.mutex.Unlock()
+
+	if window <= 0 || sinceFirst > window {
+		return
+	}
+
+	code := g.exitCode(ErrForceExit)
+
+	log.Warn().Fields(g.logFields).
+		Str(signalTag, sig.String()).
+		Dur(sinceFirstSignalTag, sinceFirst).
+		Int(exitCodeTag, code).
+		Msg(forceExitMessage)
+
+	g.setOutcome(OutcomeForcedExit)
+
+	if g.exitFlush != nil {
+		g.exitFlush()
+	}
+
+	osExit(code)
+}
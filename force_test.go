@@ -0,0 +1,89 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetForceExitWindow(t *testing.T) {
+	exited := make(chan int, 1)
+
+	osExit = func(code int) {
+		exited <- code
+	}
+
+	t.Cleanup(func() {
+		osExit = os.Exit
+	})
+
+	graceful := New()
+	graceful.SetForceExitWindow(time.Second)
+	graceful.SetExitCode(func(err error) int {
+		if errors.Is(err, ErrForceExit) {
+			return 130
+		}
+
+		return DefaultExitCode(err)
+	})
+
+	var (
+		flushed bool
+		code    int
+	)
+
+	graceful.SetExitFlush(func() {
+		flushed = true
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		select {
+		case code = <-exited:
+		case <-ctx.Done():
+		}
+
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	startedAt := time.Now()
+
+	assert.Nil(t, graceful.Wait())
+	assert.True(t, flushed)
+	assert.Equal(t, 130, code)
+	assert.Less(t, time.Since(startedAt), 2*time.Second)
+}
+
+func TestGraceful_SetForceExitWindowElapsed(t *testing.T) {
+	var codes []int
+
+	osExit = func(code int) {
+		codes = append(codes, code)
+	}
+
+	t.Cleanup(func() {
+		osExit = os.Exit
+	})
+
+	graceful := New()
+	graceful.forceExit(syscall.SIGTERM, 0)
+	assert.Empty(t, codes)
+
+	graceful.SetForceExitWindow(time.Second)
+	graceful.forceExit(syscall.SIGTERM, 3*time.Second)
+	assert.Empty(t, codes)
+
+	graceful.forceExit(syscall.SIGTERM, 500*time.Millisecond)
+	assert.Equal(t, []int{1}, codes)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+}
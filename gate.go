@@ -0,0 +1,187 @@
+package graceful
+
+import (
+	"context"
+	"sync"
+)
+
+// Gate admission control for unit of work that should not be started once shutdown is started,
+// and outstanding unit of work is drained during shutdown process.
+type Gate struct {
+	count   int
+	closed  bool
+	drained chan struct{}
+	onLeave func()
+	mutex   sync.Mutex
+}
+
+// newGate init gate that is open.
+func newGate() *Gate {
+	return &Gate{
+		drained: make(chan struct{}),
+	}
+}
+
+// NewGate init gate that is closed when shutdown is started and drained as shutdown process.
+func (g *Graceful) NewGate() *Gate {
+	gate := newGate()
+	gate.onLeave = g.inflightDrained
+
+	g.mutex.Lock()
+	g.gates = append(g.gates, gate)
+	g.mutex.Unlock()
+
+	g.registerShutdown("NewGate", newShutdown("", gate.drain))
+
+	return gate
+}
+
+// Enter enter a unit of work to gate, ok is false when the gate is already closed.
+// leave must be called when the unit of work is done.
+func (gt *Gate) Enter() (leave func(), ok bool) {
+	gt.mutex.Lock()
+	defer gt.mutex.Unlock()
+
+	if gt.closed {
+		return func() {}, false
+	}
+
+	return gt.enter(), true
+}
+
+// track enter a unit of work even when the gate is already closed, e.g. message that is already fetched.
+func (gt *Gate) track() (leave func()) {
+	gt.mutex.Lock()
+	defer gt.mutex.Unlock()
+
+	return gt.enter()
+}
+
+// enter add a unit of work to gate, gate mutex must be held.
+func (gt *Gate) enter() (leave func()) {
+	gt.count++
+
+	var once sync.Once
+
+	return func() {
+		once.Do(gt.leave)
+	}
+}
+
+// leave remove a unit of work from gate, on leave is called when the unit of work is drained after the gate is closed.
+func (gt *Gate) leave() {
+	gt.mutex.Lock()
+	gt.count--
+
+	closed := gt.closed
+	if closed && gt.count == 0 {
+		gt.closeDrained()
+	}
+	gt.mutex.Unlock()
+
+	if closed && gt.onLeave != nil {
+		gt.onLeave()
+	}
+}
+
+// inflight get number of outstanding unit of work.
+func (gt *Gate) inflight() int {
+	gt.mutex.Lock()
+	defer gt.mutex.Unlock()
+
+	return gt.count
+}
+
+// close stop accepting new unit of work.
+func (gt *Gate) close() {
+	gt.mutex.Lock()
+	defer gt.mutex.Unlock()
+
+	if gt.closed {
+		return
+	}
+
+	gt.closed = true
+
+	if gt.count == 0 {
+		gt.closeDrained()
+	}
+}
+
+// closeDrained close drained channel once, since tracked unit of work can be drained again after the gate is drained.
+// gate mutex must be held.
+func (gt *Gate) closeDrained() {
+	select {
+	case <-gt.drained:
+	default:
+		close(gt.drained)
+	}
+}
+
+// reset reopen closed gate for new Wait, outstanding unit of work is kept.
+func (gt *Gate) reset() {
+	gt.mutex.Lock()
+	defer gt.mutex.Unlock()
+
+	if !gt.closed {
+		return
+	}
+
+	gt.closed = false
+	gt.drained = make(chan struct{})
+}
+
+// drain close the gate and wait until all outstanding unit of work is done or ctx is done.
+func (gt *Gate) drain(ctx context.Context) error {
+	gt.close()
+
+	gt.mutex.Lock()
+	drained := gt.drained
+	gt.mutex.Unlock()
+
+	select {
+	case <-drained:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// SetInflightDrainCallback set callback that is called with the remaining unit of work on all gates
+// each time a unit of work is drained during shutdown process, e.g. to show drain progress.
+// the callbacks are called serially and stopped once shutdown process is done or timed out.
+func (g *Graceful) SetInflightDrainCallback(callback func(remaining int)) {
+	g.drainMutex.Lock()
+	defer g.drainMutex.Unlock()
+
+	g.inflightDrainCallback = callback
+}
+
+// inflightDrained call inflight drain callback with the remaining unit of work on all gates.
+func (g *Graceful) inflightDrained() {
+	g.drainMutex.Lock()
+	defer g.drainMutex.Unlock()
+
+	if g.inflightDrainCallback == nil || g.inflightDrainStopped {
+		return
+	}
+
+	g.mutex.Lock()
+	gates := append([]*Gate(nil), g.gates...)
+	g.mutex.Unlock()
+
+	var remaining int
+	for _, gate := range gates {
+		remaining += gate.inflight()
+	}
+
+	g.inflightDrainCallback(remaining)
+}
+
+// stopInflightDrain stop calling inflight drain callback.
+func (g *Graceful) stopInflightDrain() {
+	g.drainMutex.Lock()
+	defer g.drainMutex.Unlock()
+
+	g.inflightDrainStopped = true
+}
@@ -0,0 +1,100 @@
+package graceful
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// TerminationGracePeriodEnv env var of termination grace period that is read on init,
+// the value is in seconds like Kubernetes terminationGracePeriodSeconds or duration like 30s.
+const TerminationGracePeriodEnv = "GRACEFUL_TERMINATION_GRACE_PERIOD"
+
+// SetTerminationGracePeriod set termination grace period value, e.g. Kubernetes terminationGracePeriodSeconds.
+// when Wait is started and the whole shutdown could outlast the grace period minus safety margin,
+// a warning is logged and max shutdown time is aligned to stay under it. the safety margin is 10% of the period,
+// at least 1 second. 0 or less disable it.
+func (g *Graceful) SetTerminationGracePeriod(period time.Duration) {
+	g.terminationGracePeriod = period
+}
+
+// terminationGracePeriodFromEnv get termination grace period from TerminationGracePeriodEnv, 0 when it's not set.
+func terminationGracePeriodFromEnv() time.Duration {
+	value, ok := os.LookupEnv(TerminationGracePeriodEnv)
+	if !ok || value == "" {
+		return 0
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+
+	period, err := time.ParseDuration(value)
+	if err != nil {
+		log.Warn().Err(fmt.Errorf("%s: %w", TerminationGracePeriodEnv, err)).Msg(gracePeriodEnvMessage)
+
+		return 0
+	}
+
+	return period
+}
+
+// terminationGraceMargin get safety margin that is reserved from the termination grace period.
+func terminationGraceMargin(period time.Duration) time.Duration {
+	margin := period / 10
+	if margin < time.Second {
+		margin = time.Second
+	}
+
+	if margin > period/2 {
+		margin = period / 2
+	}
+
+	return margin
+}
+
+// alignTerminationGracePeriod align max shutdown time, so signal jitter, shutdown process and telemetry flush
+// stay under the termination grace period minus safety margin.
+func (g *Graceful) alignTerminationGracePeriod() {
+	period := g.terminationGracePeriod
+	if period <= 0 {
+		return
+	}
+
+	var telemetry time.Duration
+
+	g.mutex.Lock()
+	for _, s := range g.shutdowns {
+		if s.phase == TelemetryFlushPhase {
+			telemetry = g.telemetryFlushTimeout
+
+			break
+		}
+	}
+	g.mutex.Unlock()
+
+	var (
+		budget = period - terminationGraceMargin(period)
+		total  = g.signalJitter + g.maxShutdownTime + telemetry
+	)
+
+	if total <= budget {
+		return
+	}
+
+	aligned := budget - g.signalJitter - telemetry
+	if aligned <= 0 {
+		aligned = budget
+	}
+
+	log.Warn().Fields(g.logFields).
+		Dur(gracePeriodTag, period).
+		Dur(maxShutdownTimeTag, g.maxShutdownTime).
+		Dur(alignedShutdownTimeTag, aligned).
+		Msg(gracePeriodMessage)
+
+	g.maxShutdownTime = aligned
+}
@@ -0,0 +1,74 @@
+package graceful
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetTerminationGracePeriod(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(time.Minute)
+	graceful.SetSignalJitter(2 * time.Second)
+	graceful.SetTerminationGracePeriod(30 * time.Second)
+
+	graceful.RegisterTelemetryFlush(func(ctx context.Context) error {
+		return nil
+	})
+
+	graceful.alignTerminationGracePeriod()
+
+	// 30s - 3s margin - 2s jitter - 5s telemetry flush.
+	assert.Equal(t, 20*time.Second, graceful.maxShutdownTime)
+
+	graceful.alignTerminationGracePeriod()
+	assert.Equal(t, 20*time.Second, graceful.maxShutdownTime)
+}
+
+func TestGraceful_SetTerminationGracePeriodUnderBudget(t *testing.T) {
+	graceful := New()
+	graceful.SetTerminationGracePeriod(30 * time.Second)
+	graceful.alignTerminationGracePeriod()
+
+	assert.Equal(t, DefaultMaxShutdownTime, graceful.maxShutdownTime)
+
+	graceful.SetTerminationGracePeriod(0)
+	graceful.SetMaxShutdownTime(time.Hour)
+	graceful.alignTerminationGracePeriod()
+
+	assert.Equal(t, time.Hour, graceful.maxShutdownTime)
+}
+
+func TestGraceful_SetTerminationGracePeriodWait(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(time.Minute)
+	graceful.SetTerminationGracePeriod(5 * time.Second)
+
+	go graceful.Stop(context.Background())
+
+	assert.Nil(t, graceful.Wait())
+	assert.Equal(t, 4*time.Second, graceful.Config().MaxShutdownTime)
+}
+
+func TestTerminationGracePeriodFromEnv(t *testing.T) {
+	t.Setenv(TerminationGracePeriodEnv, "30")
+	assert.Equal(t, 30*time.Second, terminationGracePeriodFromEnv())
+	assert.Equal(t, 30*time.Second, New().Config().TerminationGracePeriod)
+
+	t.Setenv(TerminationGracePeriodEnv, "1m30s")
+	assert.Equal(t, 90*time.Second, terminationGracePeriodFromEnv())
+
+	t.Setenv(TerminationGracePeriodEnv, "invalid")
+	assert.Equal(t, time.Duration(0), terminationGracePeriodFromEnv())
+
+	t.Setenv(TerminationGracePeriodEnv, "")
+	assert.Equal(t, time.Duration(0), terminationGracePeriodFromEnv())
+}
+
+func TestTerminationGraceMargin(t *testing.T) {
+	assert.Equal(t, 3*time.Second, terminationGraceMargin(30*time.Second))
+	assert.Equal(t, time.Second, terminationGraceMargin(5*time.Second))
+	assert.Equal(t, 500*time.Millisecond, terminationGraceMargin(time.Second))
+}
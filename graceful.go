@@ -2,25 +2,156 @@ package graceful
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
 	"os"
 	"os/signal"
+	"runtime"
+	"runtime/pprof"
 	"sync"
+	"sync/atomic"
 	"time"
+	"unsafe"
 
 	"github.com/rs/zerolog/log"
 	"golang.org/x/sync/errgroup"
 )
 
+// strictNil flag to panic when register method got nil process.
+var strictNil int32
+
+// SetStrictNil set strict nil value for all graceful instance.
+// when it's true, all register methods will panic on nil process instead of silently ignore it.
+func SetStrictNil(value bool) {
+	var flag int32
+	if value {
+		flag = 1
+	}
+
+	atomic.StoreInt32(&strictNil, flag)
+}
+
+// checkNilProcess panic when strict nil is enabled, method is the register method name that got nil process.
+func checkNilProcess(method string) {
+	if atomic.LoadInt32(&strictNil) == 1 {
+		panic(fmt.Errorf("%w: %s", ErrNilProcess, method))
+	}
+}
+
+// state lifecycle state of graceful.
+type state int
+
+const (
+	// stateIdle Wait is not called yet.
+	stateIdle state = iota
+	// stateWaiting Wait is called and waiting for os signal.
+	stateWaiting
+	// stateShuttingDown shutdown process is running.
+	stateShuttingDown
+	// stateDone shutdown process is done.
+	stateDone
+)
+
+// String get name of the state.
+func (s state) String() string {
+	switch s {
+	case stateIdle:
+		return "idle"
+	case stateWaiting:
+		return "waiting"
+	case stateShuttingDown:
+		return "shutting-down"
+	default:
+		return "done"
+	}
+}
+
 // Graceful struct to hold the provided options and dependencies
 type Graceful struct {
-	groupCtx, signalCtx context.Context
-	signalCancel        context.CancelFunc
-	group               *errgroup.Group
-	shutdowns           []shutdown
-	maxShutdownTime     time.Duration
-	maxShutdownProcess  int
-	cancelOnError       bool
-	mutex               sync.Mutex
+	createdAt              time.Time
+	parentCtx              context.Context
+	groupCtx, signalCtx    context.Context
+	signalCancel           context.CancelFunc
+	signalWatcher          *signalWatcher
+	trigger                context.CancelFunc
+	postShutdownCtx        context.Context
+	postShutdownCancel     context.CancelFunc
+	group                  *errgroup.Group
+	processes              map[string]chan struct{}
+	readiness              map[string]bool
+	readinessTags          []string
+	readyChanged           chan struct{}
+	shutdowns              []shutdown
+	gates                  []*Gate
+	runningHooks           map[string]*runningHook
+	inflightDrainCallback  func(remaining int)
+	inflightDrainStopped   bool
+	events                 chan Event
+	eventsClosed           bool
+	eventMutex             sync.Mutex
+	drainMutex             sync.Mutex
+	finalizers             []func()
+	preflights             []func(ctx context.Context) error
+	launch                 chan struct{}
+	queuedProcesses        []queuedProcess
+	onStart                []func()
+	onShutdownStart        []func()
+	doneNotifies           []chan<- error
+	phases                 []string
+	phaseConcurrency       map[string]int
+	scheduler              func(hooks []ShutdownInfo) [][]ShutdownInfo
+	shutdownLimiter        func(ctx context.Context) (release func(), err error)
+	shutdownLess           func(a, b ShutdownInfo) bool
+	maxShutdownTime        time.Duration
+	softShutdownTimeout    time.Duration
+	slowHookThreshold      float64
+	maxShutdownProcess     int
+	maxShutdownWaves       int
+	batchRetry             int
+	telemetryFlushTimeout  time.Duration
+	terminationGracePeriod time.Duration
+	signals                []os.Signal
+	signalJitter           time.Duration
+	forceExitWindow        time.Duration
+	drainDelay             time.Duration
+	shutdownPacing         time.Duration
+	shutdownVeto           func(sig os.Signal) bool
+	cancelOnError          bool
+	shutdownOnError        bool
+	returnCancelCause      bool
+	reportAllErrors        bool
+	exitOnComplete         bool
+	runningProcesses       int32
+	processConcurrency     int
+	labelGoroutines        bool
+	leakDetection          bool
+	coalesceErrorLogs      bool
+	warnDuplicateFuncs     bool
+	warnSaturation         bool
+	abandonPolicy          AbandonPolicy
+	abandoned              int32
+	logFields              map[string]interface{}
+	shutdownProfile        io.Writer
+	idGenerator            func() string
+	exitCode               func(err error) int
+	exitFlush              func()
+	clock                  Clock
+	report                 ShutdownReport
+	lastShutdownErr        error
+	lastOutcome            Outcome
+	clusterLeave           func(ctx context.Context) error
+	abortOnLeaveError      bool
+	state                  state
+	done                   chan struct{}
+	waitErr                error
+	shutdownGuard          sync.Once
+	shutdownStartedAt      time.Time
+	shutdownErr            error
+	entryPoint             string
+	signalLoopStopped      bool
+	mutex                  sync.Mutex
 }
 
 // New initiate graceful using context background.
@@ -32,25 +163,130 @@ func New(signals ...os.Signal) *Graceful {
 
 // NewWithContext initiate graceful with context param.
 // create signal waiting from os signal that will be triggered when some signal is called.
+// os signals that can't be handled on the current os, e.g. SIGHUP on windows, are skipped with a log.
+// when ctx is already done, Wait will run the shutdown process right away and a warning is logged,
+// use NewWithContextE to get an error instead.
 func NewWithContext(ctx context.Context, signals ...os.Signal) *Graceful {
-	if len(signals) == 0 {
+	isDefault := len(signals) == 0
+	if isDefault {
 		signals = defaultSignals
 	}
 
-	var (
-		signalCtx, signalCancel = signal.NotifyContext(ctx, signals...)
-		group, groupCtx         = errgroup.WithContext(signalCtx)
-	)
+	signals = supportedSignals(signals, isDefault)
+
+	if err := ctx.Err(); err != nil {
+		log.Warn().Err(err).Msg(contextDoneMessage)
+	}
+
+	return newGraceful(ctx, signals)
+}
+
+// NewWithContextE initiate graceful like NewWithContext, but return ErrContextDone when ctx is already done,
+// so the background process is not started with zero running time.
+func NewWithContextE(ctx context.Context, signals ...os.Signal) (*Graceful, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, wrapSentinel(ErrContextDone, err)
+	}
+
+	return NewWithContext(ctx, signals...), nil
+}
+
+// NewFromContext initiate graceful that use ctx cancellation as the shutdown trigger
+// without handling any os signal, use it when the host app already owns os signal handling,
+// e.g. ctx from its own signal.NotifyContext, otherwise use NewWithContext.
+func NewFromContext(ctx context.Context) *Graceful {
+	return newGraceful(ctx, nil)
+}
+
+// newGraceful init graceful using parent context, os signals are handled when signals is not empty.
+func newGraceful(parentCtx context.Context, signals []os.Signal) *Graceful {
+	g := &Graceful{
+		parentCtx:              parentCtx,
+		createdAt:              time.Now(),
+		readiness:              make(map[string]bool),
+		readyChanged:           make(chan struct{}),
+		shutdowns:              make([]shutdown, 0),
+		phaseConcurrency:       make(map[string]int),
+		signals:                signals,
+		maxShutdownTime:        DefaultMaxShutdownTime,
+		maxShutdownProcess:     DefaultMaxShutdownProcess,
+		maxShutdownWaves:       DefaultMaxShutdownWaves,
+		telemetryFlushTimeout:  DefaultTelemetryFlushTimeout,
+		terminationGracePeriod: terminationGracePeriodFromEnv(),
+		shutdownOnError:        true,
+		exitCode:               DefaultExitCode,
+		idGenerator:            newID,
+		clock:                  realClock{},
+	}
+
+	g.arm()
+
+	return g
+}
+
+// arm init signal handling, background process group and lifecycle state for new Wait.
+func (g *Graceful) arm() {
+	if len(g.signals) > 0 {
+		watcher, signalCtx := newSignalWatcher(g.parentCtx, g.signals)
+		watcher.setOnSignal(g.emitSignal)
+		watcher.setOnRepeat(g.forceExit)
+		watcher.setVeto(g.shutdownVeto)
 
-	return &Graceful{
-		groupCtx:           groupCtx,
-		signalCtx:          signalCtx,
-		signalCancel:       signalCancel,
-		group:              group,
-		shutdowns:          make([]shutdown, 0),
-		maxShutdownTime:    defaultMaxShutdownTime,
-		maxShutdownProcess: defaultMaxShutdownProcess,
+		g.signalWatcher, g.signalCtx = watcher, signalCtx
+		g.trigger, g.signalCancel = watcher.cancel, watcher.stop
+	} else {
+		g.signalCtx, g.signalCancel = context.WithCancel(g.parentCtx)
+		g.trigger = g.signalCancel
 	}
+
+	g.group, g.groupCtx = errgroup.WithContext(g.signalCtx)
+	g.group.SetLimit(processLimit(g.processConcurrency))
+	g.postShutdownCtx, g.postShutdownCancel = context.WithCancel(context.Background())
+	g.processes = make(map[string]chan struct{})
+	g.launch = make(chan struct{})
+	g.queuedProcesses = nil
+	g.runningProcesses = 0
+	g.state = stateIdle
+	g.done = make(chan struct{})
+	g.waitErr = nil
+	g.shutdownGuard = sync.Once{}
+	g.shutdownStartedAt = time.Time{}
+	g.shutdownErr = nil
+	g.entryPoint = ""
+	g.signalLoopStopped = false
+
+	g.eventMutex.Lock()
+	g.events = make(chan Event, eventBufferSize)
+	g.eventsClosed = false
+	g.eventMutex.Unlock()
+
+	g.drainMutex.Lock()
+	g.inflightDrainStopped = false
+	g.drainMutex.Unlock()
+
+	for _, gate := range g.gates {
+		gate.reset()
+	}
+}
+
+// Reset rebuild signal handling and background process group, so graceful can be reused for new Wait.
+// registered shutdown process, options and the last shutdown report are kept, while background process
+// must be registered again. gates are reopened, and the old signal handling is stopped when Wait is never called.
+// it returns ErrAlreadyWaiting when Wait is still running, and must not be called concurrently with other methods.
+func (g *Graceful) Reset() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	switch g.state {
+	case stateWaiting, stateShuttingDown:
+		return ErrAlreadyWaiting
+	case stateIdle:
+		g.signalCancel()
+	}
+
+	g.arm()
+
+	return nil
 }
 
 // SetCancelOnError set cancel on error value.
@@ -58,10 +294,32 @@ func (g *Graceful) SetCancelOnError(value bool) {
 	g.cancelOnError = value
 }
 
+// SetRunShutdownOnProcessError set run shutdown on process error value.
+// when it's false, shutdown process is skipped when Wait is stopped by background process error
+// instead of os signal, so the app can abort fast on crash. the default value is true.
+func (g *Graceful) SetRunShutdownOnProcessError(value bool) {
+	g.shutdownOnError = value
+}
+
+// SetReturnCancelCause set return cancel cause value.
+// when it's true, background process that returns the signal context error, e.g. context.Canceled,
+// is not treated as clean exit, so Wait returns it instead of nil to tell the shutdown is context driven.
+// shutdown process error still takes precedence over it. the default value is false.
+func (g *Graceful) SetReturnCancelCause(value bool) {
+	g.returnCancelCause = value
+}
+
+// SetReportAllErrors set report all errors value.
+// when it's true, all failed shutdown process errors are returned joined using errors.Join,
+// even when they don't cancel other shutdown processes. the default value is false.
+func (g *Graceful) SetReportAllErrors(value bool) {
+	g.reportAllErrors = value
+}
+
 // SetMaxShutdownTime set max shutdown time value.
 func (g *Graceful) SetMaxShutdownTime(duration time.Duration) {
 	if duration < 1 {
-		g.maxShutdownTime = defaultMaxShutdownTime
+		g.maxShutdownTime = DefaultMaxShutdownTime
 
 		return
 	}
@@ -69,10 +327,33 @@ func (g *Graceful) SetMaxShutdownTime(duration time.Duration) {
 	g.maxShutdownTime = duration
 }
 
+// SetSoftShutdownTimeout set soft shutdown timeout value.
+// when it's reached, shutdown process context is cancelled to ask the shutdown process to stop,
+// but shutdown is still waiting the shutdown process until max shutdown time.
+// value that is 0 or greater than max shutdown time will be clamped to max shutdown time.
+func (g *Graceful) SetSoftShutdownTimeout(duration time.Duration) {
+	if duration < 0 {
+		duration = 0
+	}
+
+	g.softShutdownTimeout = duration
+}
+
+// SetSlowHookThreshold set slow hook threshold value.
+// when it's set, a warning is logged for shutdown process that is still running after the fraction of its deadline,
+// value outside of 0 and 1 will disable it. it's disabled by default.
+func (g *Graceful) SetSlowHookThreshold(fraction float64) {
+	if fraction <= 0 || fraction >= 1 {
+		fraction = 0
+	}
+
+	g.slowHookThreshold = fraction
+}
+
 // SetMaxShutdownProcess set max shutdown process value.
 func (g *Graceful) SetMaxShutdownProcess(max int) {
 	if max < 1 {
-		g.maxShutdownProcess = defaultMaxShutdownProcess
+		g.maxShutdownProcess = DefaultMaxShutdownProcess
 
 		return
 	}
@@ -80,101 +361,1301 @@ func (g *Graceful) SetMaxShutdownProcess(max int) {
 	g.maxShutdownProcess = max
 }
 
+// SetShutdownPhases set order of shutdown phases.
+// shutdown process without phase is run first, and phase that is not defined here
+// is run after the defined phases in registration order.
+func (g *Graceful) SetShutdownPhases(phases ...string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.phases = append([]string(nil), phases...)
+}
+
+// SetPhaseConcurrency set max shutdown process that can run concurrently on the phase.
+// limit less than 1 will reset it to use max shutdown process value.
+func (g *Graceful) SetPhaseConcurrency(phase string, limit int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if limit < 1 {
+		delete(g.phaseConcurrency, phase)
+
+		return
+	}
+
+	g.phaseConcurrency[phase] = limit
+}
+
+// SetShutdownScheduler set shutdown scheduler that take registered shutdown process in registration order
+// and return batches to run, the batches are run sequentially while shutdown process in the same batch is run concurrently.
+// batch concurrency use the phase concurrency when all shutdown process in the batch is on the same phase.
+// shutdown process that is not returned is skipped, nil scheduler will reset it to run the batches by phase.
+func (g *Graceful) SetShutdownScheduler(scheduler func(hooks []ShutdownInfo) [][]ShutdownInfo) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.scheduler = scheduler
+}
+
+// SetShutdownLess set comparator that sort shutdown process before they're planned into batches,
+// so shutdown process in the same phase are started in the sorted order, e.g. by tag prefix or registration time.
+// the sort is stable, so equal shutdown process keep registration order, and shutdown scheduler gets the sorted
+// shutdown process. combine it with SetMaxShutdownProcess(1) for strict sequential order. nil less will reset it
+// to registration order, which is the default value.
+func (g *Graceful) SetShutdownLess(less func(a, b ShutdownInfo) bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.shutdownLess = less
+}
+
+// SetMaxShutdownWaves set max shutdown waves value.
+// shutdown process registered by another shutdown process is run in the next wave,
+// and shutdown process registered after the max wave is skipped.
+func (g *Graceful) SetMaxShutdownWaves(max int) {
+	if max < 1 {
+		g.maxShutdownWaves = DefaultMaxShutdownWaves
+
+		return
+	}
+
+	g.maxShutdownWaves = max
+}
+
+// SetShutdownBatchRetry set shutdown batch retry value.
+// after all shutdown process are run, only the failed ones are run again up to attempts times
+// within max shutdown time, 0 or less disable it.
+func (g *Graceful) SetShutdownBatchRetry(attempts int) {
+	g.batchRetry = attempts
+}
+
+// SetSignalJitter set max signal jitter value.
+// shutdown process will wait random duration between 0 and max after got os signal,
+// and second os signal will skip the waiting.
+func (g *Graceful) SetSignalJitter(max time.Duration) {
+	if max < 0 {
+		max = 0
+	}
+
+	g.signalJitter = max
+}
+
+// SetLabelGoroutines set label goroutines value.
+// when it's true, each shutdown process goroutine is labeled with its tag using pprof labels,
+// so goroutine dumps and profiles show which shutdown process each goroutine belongs to.
+func (g *Graceful) SetLabelGoroutines(value bool) {
+	g.labelGoroutines = value
+}
+
+// SetLeakDetection set leak detection value.
+// when it's true, goroutine count is recorded at the start and the end of shutdown process
+// and a warning is logged when it's grew.
+func (g *Graceful) SetLeakDetection(value bool) {
+	g.leakDetection = value
+}
+
+// SetLogFields set structured fields that are added to every shutdown process log, e.g. service name and version.
+// nil fields will reset it.
+func (g *Graceful) SetLogFields(fields map[string]interface{}) {
+	if fields == nil {
+		g.logFields = nil
+
+		return
+	}
+
+	g.logFields = make(map[string]interface{}, len(fields))
+	for key, value := range fields {
+		g.logFields[key] = value
+	}
+}
+
+// SetCoalesceErrorLogs set coalesce error logs value.
+// when it's true, shutdown process error logs are buffered until shutdown is done,
+// and identical error message is logged once with the count and the tags.
+func (g *Graceful) SetCoalesceErrorLogs(value bool) {
+	g.coalesceErrorLogs = value
+}
+
+// SetShutdownProfile set writer for cpu profile of shutdown process.
+// when it's set, cpu profile is captured from the start until the end of shutdown process,
+// failure to start the profile is logged without aborting the shutdown process, nil writer will disable it.
+func (g *Graceful) SetShutdownProfile(w io.Writer) {
+	g.shutdownProfile = w
+}
+
+// SetIDGenerator set id generator for shutdown process.
+// nil value will reset it to default random hex id.
+func (g *Graceful) SetIDGenerator(generator func() string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if generator == nil {
+		g.idGenerator = newID
+
+		return
+	}
+
+	g.idGenerator = generator
+}
+
 // RegisterProcess register running process to background.
 func (g *Graceful) RegisterProcess(process func() error) {
 	if process == nil {
+		checkNilProcess("RegisterProcess")
+
+		return
+	}
+
+	if g.isDone("RegisterProcess") {
+		return
+	}
+
+	g.goProcess(func() error {
+		return g.processResult(process())
+	})
+}
+
+// RegisterProcessIgnoringErrors register running process to background that treat ignore errors as success,
+// e.g. http.ErrServerClosed from ListenAndServe. the errors are matched using errors.Is.
+func (g *Graceful) RegisterProcessIgnoringErrors(process func() error, ignore ...error) {
+	if process == nil {
+		checkNilProcess("RegisterProcessIgnoringErrors")
+
 		return
 	}
 
-	g.group.Go(process)
+	if g.isDone("RegisterProcessIgnoringErrors") {
+		return
+	}
+
+	g.goProcess(func() error {
+		err := process()
+
+		for _, ignored := range ignore {
+			if errors.Is(err, ignored) {
+				return nil
+			}
+		}
+
+		return g.processResult(err)
+	})
 }
 
 // RegisterProcessWithContext register running process to background with context param.
-// context is from signal and
+// context is cancelled on every shutdown trigger: os signal, Stop, parent context cancellation,
+// ErrStopRequested or error from other background process.
 func (g *Graceful) RegisterProcessWithContext(process func(ctx context.Context) error) {
 	if process == nil {
+		checkNilProcess("RegisterProcessWithContext")
+
+		return
+	}
+
+	if g.isDone("RegisterProcessWithContext") {
+		return
+	}
+
+	g.goProcess(func() error {
+		return g.processResult(process(g.groupCtx))
+	})
+}
+
+// RegisterProcessWithSignalContext register running process to background with signal context param.
+// unlike RegisterProcessWithContext, context is not cancelled right away by error from other background process,
+// it's cancelled on os signal, Stop, parent context cancellation, ErrStopRequested or once shutdown process is run.
+func (g *Graceful) RegisterProcessWithSignalContext(process func(ctx context.Context) error) {
+	if process == nil {
+		checkNilProcess("RegisterProcessWithSignalContext")
+
 		return
 	}
 
-	g.group.Go(func() error {
-		return process(g.groupCtx)
+	if g.isDone("RegisterProcessWithSignalContext") {
+		return
+	}
+
+	g.goProcess(func() error {
+		return g.processResult(process(g.signalCtx))
 	})
 }
 
+// processResult handle background process error, ErrStopRequested trigger shutdown process without error.
+// signal context error, e.g. context.Canceled from parent context cancellation, is treated as clean exit,
+// so Wait returns only the shutdown process result, unless return cancel cause is enabled.
+func (g *Graceful) processResult(err error) error {
+	if errors.Is(err, ErrStopRequested) {
+		g.trigger()
+
+		return nil
+	}
+
+	if g.isCancelCause(err) && !g.returnCancelCause {
+		return nil
+	}
+
+	return err
+}
+
+// isCancelCause check whether err is the signal context error after the signal context is done.
+func (g *Graceful) isCancelCause(err error) bool {
+	signalErr := g.signalCtx.Err()
+
+	return signalErr != nil && errors.Is(err, signalErr)
+}
+
 // RegisterShutdownProcess register shutdown process that will be called when got some os signal.
 func (g *Graceful) RegisterShutdownProcess(process func(context.Context) error) string {
-	return g.RegisterShutdownProcessWithTag(process, "")
+	return g.registerShutdown("RegisterShutdownProcess", newUserShutdown("", process))
 }
 
 // RegisterShutdownProcessWithTag register shutdown process using tag.
+// duplicate tag is suffixed with its occurrence, e.g. http-server#2, and ErrDuplicateTag is logged.
 func (g *Graceful) RegisterShutdownProcessWithTag(process func(context.Context) error, tag string) string {
-	if process == nil {
+	return g.registerShutdown("RegisterShutdownProcessWithTag", newUserShutdown(tag, process))
+}
+
+// RegisterShutdownProcessWithPhase register shutdown process using tag on shutdown phase.
+// phases are run sequentially using order from SetShutdownPhases.
+func (g *Graceful) RegisterShutdownProcessWithPhase(process func(context.Context) error, tag, phase string) string {
+	shutdownProcess := newUserShutdown(tag, process)
+	shutdownProcess.phase = phase
+
+	return g.registerShutdown("RegisterShutdownProcessWithPhase", shutdownProcess)
+}
+
+// registerShutdown register shutdown process, method is the register method name used on error.
+func (g *Graceful) registerShutdown(method string, shutdownProcess shutdown) string {
+	if shutdownProcess.process == nil {
+		checkNilProcess(method)
+
 		return ""
 	}
 
 	g.mutex.Lock()
 	defer g.mutex.Unlock()
 
-	shutdownProcess, id := newShutdown(tag, process)
+	if g.state == stateDone {
+		logRegisterError(method, ErrRegisterAfterShutdown)
+
+		return ""
+	}
+
+	if shutdownProcess.tag != "" {
+		shutdownProcess.tag = uniqueTag(method, shutdownProcess.tag, g.hasTag)
+	}
+
+	shutdownProcess.id = g.idGenerator()
+	if shutdownProcess.tag == "" {
+		shutdownProcess.tag = shutdownProcess.id
+	}
+
+	if g.warnDuplicateFuncs {
+		g.warnDuplicateFunc(shutdownProcess)
+	}
+
 	g.shutdowns = append(g.shutdowns, shutdownProcess)
 
-	return id.String()
+	return shutdownProcess.id
 }
 
-// shutdown handle all shutdown process with concurrency.
-func (g *Graceful) shutdown() error {
-	shutdownCtx, shutdownCancel := context.WithTimeout(context.Background(), g.maxShutdownTime)
-	defer shutdownCancel()
+// Shutdowns get info of registered shutdown process in registration order.
+func (g *Graceful) Shutdowns() []ShutdownInfo {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	infos := make([]ShutdownInfo, 0, len(g.shutdowns))
+	for _, s := range g.shutdowns {
+		infos = append(infos, s.info())
+	}
 
-	shutdownGroup, shutdownGroupCtx := errgroup.WithContext(shutdownCtx)
-	shutdownGroup.SetLimit(g.maxShutdownProcess)
+	return infos
+}
+
+// SetWarnConcurrencySaturation set warn concurrency saturation value.
+// when it's true, a warning is logged after shutdown process when the number of shutdown process far exceed
+// max shutdown process and they spent more time waiting for concurrency slot than the whole shutdown process,
+// suggesting to raise the limit. it's reported as ConcurrencySaturated either way. the default value is false.
+func (g *Graceful) SetWarnConcurrencySaturation(value bool) {
+	g.warnSaturation = value
+}
+
+// SetWarnDuplicateFuncs set warn duplicate funcs value.
+// when it's enabled, registering shutdown process using the same function value as registered one logs a warning,
+// e.g. registering closeDatabase twice under different tags. function values are compared by identity,
+// so method values, e.g. db.Close, and closures that are created separately are never detected,
+// even when they close the same resource. shutdown process registered by helpers is never checked.
+func (g *Graceful) SetWarnDuplicateFuncs(value bool) {
+	g.warnDuplicateFuncs = value
+}
+
+// warnDuplicateFunc log a warning when the shutdown process function is already registered, must be called with mutex locked.
+func (g *Graceful) warnDuplicateFunc(shutdownProcess shutdown) {
+	if shutdownProcess.origin == nil {
+		return
+	}
+
+	identity := funcIdentity(shutdownProcess.origin)
 
 	for _, s := range g.shutdowns {
-		shutdownCopy := s
+		if s.origin != nil && funcIdentity(s.origin) == identity {
+			log.Warn().Str(shutdownTag, shutdownProcess.tag).Str(duplicateOfTag, s.tag).Msg(duplicateFuncMessage)
 
-		shutdownGroup.Go(func() error {
-			errChan := make(chan error)
+			return
+		}
+	}
+}
 
-			go func() {
-				err := shutdownCopy.process(shutdownGroupCtx)
-				errChan <- err
-			}()
+// funcIdentity get pointer of function value. unlike its code pointer, it's different for closures
+// of the same function literal and method values of different receivers.
+func funcIdentity(fn func(context.Context) error) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&fn))
+}
 
-			select {
-			case <-shutdownGroupCtx.Done():
-				return shutdownGroupCtx.Err()
-			case err := <-errChan:
-				if err != nil {
-					log.Error().Str(shutdownTag, shutdownCopy.tag).Err(err).Send()
-				} else {
-					log.Info().Str(shutdownTag, shutdownCopy.tag).Msg(shutdownSuccessMessage)
-				}
+// ShutdownIDs get id of registered shutdown process in registration order.
+func (g *Graceful) ShutdownIDs() []string {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 
-				if g.cancelOnError {
-					return err
-				}
-			}
+	ids := make([]string, 0, len(g.shutdowns))
+	for _, s := range g.shutdowns {
+		ids = append(ids, s.id)
+	}
 
-			return nil
-		})
+	return ids
+}
+
+// Unregister remove registered shutdown process using id returned by register method,
+// it returns false when the id is not registered or shutdown process is already started.
+func (g *Graceful) Unregister(id string) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if !g.shutdownStartedAt.IsZero() {
+		return false
 	}
 
-	return shutdownGroup.Wait()
+	for i, s := range g.shutdowns {
+		if s.id == id {
+			g.shutdowns = append(g.shutdowns[:i:i], g.shutdowns[i+1:]...)
+
+			return true
+		}
+	}
+
+	return false
 }
 
-// Wait waiting for os signal send and call shutdown process when got some signal.
-func (g *Graceful) Wait() error {
-	defer g.signalCancel()
+// isDone check whether shutdown process is done and log the error for register method.
+func (g *Graceful) isDone(method string) bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
 
-	g.group.Go(func() error {
-		<-g.groupCtx.Done()
+	if g.state == stateDone {
+		logRegisterError(method, ErrRegisterAfterShutdown)
+
+		return true
+	}
+
+	return false
+}
 
-		if len(g.shutdowns) > 0 {
-			return g.shutdown()
+// hasTag check whether shutdown process tag is already registered, must be called with mutex locked.
+func (g *Graceful) hasTag(tag string) bool {
+	for _, s := range g.shutdowns {
+		if s.tag == tag {
+			return true
 		}
+	}
 
-		return nil
-	})
+	return false
+}
+
+// uniqueTag get tag that is not used yet, duplicate tag is suffixed with its occurrence, e.g. http-server#2,
+// and ErrDuplicateTag is logged as warning, so the duplicate is still registered instead of dropped.
+func uniqueTag(method, tag string, used func(tag string) bool) string {
+	if !used(tag) {
+		return tag
+	}
+
+	for occurrence := 2; ; occurrence++ {
+		unique := fmt.Sprintf("%s#%d", tag, occurrence)
+		if used(unique) {
+			continue
+		}
+
+		log.Warn().Str(registerMethodTag, method).Str(registeredTagTag, unique).
+			Err(fmt.Errorf("%w: %s", ErrDuplicateTag, tag)).Send()
+
+		return unique
+	}
+}
+
+// setState set lifecycle state of graceful.
+func (g *Graceful) setState(value state) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.state = value
+}
 
-	return g.group.Wait()
+// logRegisterError log error from register method that can't return error.
+func logRegisterError(method string, err error) {
+	log.Error().Str(registerMethodTag, method).Err(err).Send()
+}
+
+// EffectiveShutdownConcurrency get number of shutdown process that can run concurrently,
+// which is max shutdown process clamped to the number of registered shutdown process.
+func (g *Graceful) EffectiveShutdownConcurrency() int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if len(g.shutdowns) < g.maxShutdownProcess {
+		return len(g.shutdowns)
+	}
+
+	return g.maxShutdownProcess
+}
+
+// Uptime get duration since graceful is created.
+func (g *Graceful) Uptime() time.Duration {
+	return time.Since(g.createdAt)
+}
+
+// ShutdownStartedAt get time when shutdown process is started and whether it's already started.
+func (g *Graceful) ShutdownStartedAt() (time.Time, bool) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.shutdownStartedAt, !g.shutdownStartedAt.IsZero()
+}
+
+// LastShutdownReport get report of the last shutdown process.
+func (g *Graceful) LastShutdownReport() ShutdownReport {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	report := g.report
+	report.Hooks = append([]HookReport(nil), g.report.Hooks...)
+	report.Skipped = append([]SkippedHook(nil), g.report.Skipped...)
+
+	return report
+}
+
+// LastShutdownError get the first error of the last shutdown process, even when Wait doesn't return it,
+// while Wait returns all of them joined when report all errors is enabled, so alert can show a concise primary failure.
+// it's nil when the last shutdown process is succeeded or no shutdown process is run yet.
+func (g *Graceful) LastShutdownError() error {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.lastShutdownErr
+}
+
+// firstError get the first error of joined errors, other error is returned as is.
+func firstError(err error) error {
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		if errs := joined.Unwrap(); len(errs) > 0 {
+			return errs[0]
+		}
+	}
+
+	return err
+}
+
+// shutdown handle all shutdown process with concurrency.
+// all failed shutdown process errors are joined using errors.Join for the return value when report all errors is enabled.
+func (g *Graceful) shutdown() error {
+	startedAt, _ := g.ShutdownStartedAt()
+
+	var (
+		concurrency      = g.EffectiveShutdownConcurrency()
+		recorder         = newHookRecorder(len(g.shutdowns))
+		run              = &shutdownRun{recorder: recorder, reportAll: g.reportAllErrors}
+		goroutinesBefore int
+	)
+
+	if g.coalesceErrorLogs {
+		run.errorLogs = newErrorLogBuffer()
+		defer run.errorLogs.flush(g.logFields)
+	}
+
+	if g.shutdownProfile != nil {
+		if err := pprof.StartCPUProfile(g.shutdownProfile); err != nil {
+			log.Warn().Fields(g.logFields).Err(err).Msg(shutdownProfileMessage)
+		} else {
+			defer pprof.StopCPUProfile()
+		}
+	}
+
+	if g.leakDetection {
+		goroutinesBefore = runtime.NumGoroutine()
+	}
+
+	g.closeGates()
+
+	defer g.stopInflightDrain()
+	defer func() {
+		report := ShutdownReport{
+			StartedAt:            startedAt,
+			Total:                g.clock.Now().Sub(startedAt),
+			EffectiveConcurrency: concurrency,
+			Hooks:                recorder.list(),
+			Skipped:              recorder.listSkipped(),
+			SignalCounts:         signalCountsReport(g.SignalCounts()),
+			EntryPoint:           g.ShutdownEntryPoint(),
+		}
+
+		report.PeakConcurrency, report.BlockedTime = run.concurrency()
+		report.ConcurrencySaturated = concurrencySaturated(len(report.Hooks), g.maxShutdownProcess, report.BlockedTime, report.Total)
+
+		if report.ConcurrencySaturated && g.warnSaturation {
+			log.Warn().Fields(g.logFields).
+				Int(hookCountTag, len(report.Hooks)).
+				Int(maxShutdownProcessTag, g.maxShutdownProcess).
+				Dur(blockedTimeTag, report.BlockedTime).
+				Msg(concurrencySaturatedMessage)
+		}
+		report.BatchRetries = run.retries
+		report.Abandoned = run.abandonedCount()
+		report.Results = run.resultsReport()
+		report.Groups = run.groupsReport()
+		report.Uptime = g.Uptime()
+
+		if g.leakDetection {
+			report.GoroutinesBefore = goroutinesBefore
+			report.GoroutinesAfter = settledNumGoroutine(goroutinesBefore)
+			report.GoroutineDelta = report.GoroutinesAfter - goroutinesBefore
+
+			if report.GoroutineDelta > 0 {
+				log.Warn().Fields(g.logFields).Int(goroutineDeltaTag, report.GoroutineDelta).Msg(goroutineLeakMessage)
+			}
+		}
+
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+
+		g.report = report
+	}()
+
+	shutdownCtx, shutdownCancel := g.clock.WithTimeout(context.Background(), g.maxShutdownTime)
+	defer shutdownCancel()
+
+	// shutdown deadline is the earlier of max shutdown time and the parent context deadline.
+	deadline := startedAt.Add(g.maxShutdownTime)
+	if parentDeadline, ok := g.signalCtx.Deadline(); ok && parentDeadline.Before(deadline) {
+		deadline = parentDeadline
+
+		var parentCancel context.CancelFunc
+
+		shutdownCtx, parentCancel = g.clock.WithTimeout(shutdownCtx, parentDeadline.Sub(g.clock.Now()))
+		defer parentCancel()
+	}
+
+	if softDeadline := startedAt.Add(g.softShutdownTimeout); g.softShutdownTimeout > 0 && softDeadline.Before(deadline) {
+		run.softDeadline = softDeadline
+	}
+
+	// cluster leave is run before the drain delay, so the node leaves the cluster as early as possible.
+	aborted, leaveErr := g.leaveCluster(shutdownCtx, run)
+
+	g.waitDrainDelay(shutdownCtx)
+
+	var (
+		reserved = make(map[string][]shutdown)
+		err      error
+	)
+
+	// shutdown process can register another shutdown process,
+	// so keep running new registered shutdown process wave by wave.
+waves:
+	for wave, next := 0, 0; ; wave++ {
+		g.mutex.Lock()
+		shutdowns := append([]shutdown(nil), g.shutdowns[next:]...)
+		next = len(g.shutdowns)
+		g.mutex.Unlock()
+
+		if len(shutdowns) == 0 {
+			break
+		}
+
+		if wave >= g.maxShutdownWaves {
+			log.Warn().Fields(g.logFields).Int(shutdownSkippedTag, len(shutdowns)).Msg(maxShutdownWavesMessage)
+			recorder.skip(shutdowns, SkipReasonMaxWaves)
+
+			break
+		}
+
+		shutdowns, unmatched := g.matchSignal(shutdowns)
+		recorder.skip(unmatched, SkipReasonSignal)
+
+		shutdowns, waveReserved := splitReserved(shutdowns)
+		for phase, phaseShutdowns := range waveReserved {
+			reserved[phase] = append(reserved[phase], phaseShutdowns...)
+		}
+
+		if aborted {
+			recorder.skip(shutdowns, SkipReasonAborted)
+			err = leaveErr
+
+			break
+		}
+
+		batches, unscheduled := g.planShutdown(shutdowns)
+		recorder.skip(unscheduled, SkipReasonUnscheduled)
+
+		for i, batch := range batches {
+			if err = g.runShutdownBatch(shutdownCtx, batch, run); err != nil {
+				for _, skipped := range batches[i+1:] {
+					recorder.skip(skipped.shutdowns, SkipReasonAborted)
+				}
+
+				g.mutex.Lock()
+				recorder.skip(g.shutdowns[next:], SkipReasonAborted)
+				g.mutex.Unlock()
+
+				break waves
+			}
+		}
+	}
+
+	if err == nil {
+		err = g.retryShutdown(shutdownCtx, run)
+	}
+
+	// admin server is kept serving until all other shutdown process are done, so operators can inspect the shutdown.
+	for _, batch := range g.planShutdownPhases(reserved[AdminServerPhase]) {
+		if adminErr := g.runShutdownBatch(shutdownCtx, batch, run); err == nil {
+			err = adminErr
+		}
+	}
+
+	if flushErr := g.flushTelemetry(reserved[TelemetryFlushPhase], run); err == nil {
+		err = flushErr
+	}
+
+	// log flusher is run after all other shutdown process even when they're aborted,
+	// so their log lines are captured before the buffer is flushed.
+	for _, batch := range g.planShutdownPhases(reserved[LogFlushPhase]) {
+		if flushErr := g.runShutdownBatch(shutdownCtx, batch, run); err == nil {
+			err = flushErr
+		}
+	}
+
+	// the first failure is kept even when it's not returned, see SetReportAllErrors.
+	lastErr := run.failedErr()
+	if lastErr == nil {
+		lastErr = err
+	}
+
+	g.mutex.Lock()
+	g.lastShutdownErr = firstError(lastErr)
+	g.mutex.Unlock()
+
+	return run.result(err)
+}
+
+// retryShutdown run failed shutdown process again up to shutdown batch retry attempts while ctx is not done,
+// shutdown process that is succeeded is not run again.
+func (g *Graceful) retryShutdown(ctx context.Context, run *shutdownRun) error {
+	for attempt := 1; attempt <= g.batchRetry && ctx.Err() == nil; attempt++ {
+		failed := run.failedShutdowns()
+		if len(failed) == 0 {
+			return nil
+		}
+
+		for i := range failed {
+			failed[i].retry = attempt
+		}
+
+		run.retries = attempt
+		log.Warn().Fields(g.logFields).Int(shutdownRetryTag, attempt).Int(errorCountTag, len(failed)).Msg(shutdownRetryMessage)
+
+		batches, _ := g.planShutdown(failed)
+
+		for _, batch := range batches {
+			if err := g.runShutdownBatch(ctx, batch, run); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// shutdownOnce run shutdown process exactly once even when it's triggered by multiple sources,
+// and return the same result for every call.
+func (g *Graceful) shutdownOnce() error {
+	g.shutdownGuard.Do(func() {
+		// signal context is cancelled once shutdown is started, e.g. by DrainNow,
+		// so the background processes are returned and Wait isn't blocked on them.
+		g.trigger()
+
+		g.mutex.Lock()
+		g.shutdownStartedAt = g.clock.Now()
+		g.lastShutdownErr = nil
+		// cluster leave and drain delay are still run without any shutdown process.
+		empty := len(g.shutdowns) == 0 && g.clusterLeave == nil && g.drainDelay <= 0
+		g.mutex.Unlock()
+
+		g.emit(Event{Type: EventShutdownBegan})
+
+		if !empty {
+			g.shutdownErr = g.shutdown()
+		}
+	})
+
+	return g.shutdownErr
+}
+
+// runShutdownBatch run shutdown process in the batch concurrently and wait until all of them are done.
+func (g *Graceful) runShutdownBatch(ctx context.Context, batch shutdownBatch, run *shutdownRun) error {
+	g.mutex.Lock()
+	limiter := g.shutdownLimiter
+	g.mutex.Unlock()
+
+	// shutdown limiter is used in place of the batch limit.
+	shutdownGroup, shutdownGroupCtx := errgroup.WithContext(ctx)
+	if limiter == nil {
+		shutdownGroup.SetLimit(batch.limit)
+	}
+
+	// process context is cancelled on soft shutdown timeout to ask shutdown process to stop,
+	// while shutdown group context is still waiting until max shutdown time.
+	// telemetry flush has its own timeout, so soft shutdown timeout is not applied.
+	processCtx, processCancel := shutdownGroupCtx, context.CancelFunc(func() {})
+	if !run.softDeadline.IsZero() && batch.phase != TelemetryFlushPhase {
+		processCtx, processCancel = g.clock.WithTimeout(shutdownGroupCtx, run.softDeadline.Sub(g.clock.Now()))
+	}
+
+	defer processCancel()
+
+	// all shutdown process in the batch is queued at once, then waiting for concurrency slot.
+	// the time spent on pacing is not counted as waiting for concurrency slot.
+	var (
+		queuedAt = g.clock.Now()
+		pacing   = g.pacingInterval(shutdownGroupCtx, len(batch.shutdowns))
+	)
+
+	for i, s := range batch.shutdowns {
+		shutdownCopy := s
+
+		if i > 0 && pacing > 0 {
+			pausedAt := g.clock.Now()
+			g.waitPacing(shutdownGroupCtx, pacing)
+			queuedAt = queuedAt.Add(g.clock.Now().Sub(pausedAt))
+		}
+
+		shutdownQueuedAt := queuedAt
+
+		shutdownGroup.Go(func() error {
+			if limiter != nil {
+				release, ok := g.acquireShutdown(shutdownGroupCtx, limiter, shutdownCopy, run)
+				if !ok {
+					return nil
+				}
+
+				defer release()
+			}
+
+			leave := run.enter(g.clock.Now().Sub(shutdownQueuedAt))
+			defer leave()
+
+			if !g.labelGoroutines {
+				return g.runShutdownProcess(shutdownGroupCtx, processCtx, shutdownCopy, run)
+			}
+
+			var (
+				err    error
+				labels = pprof.Labels(goroutineLabelKey, shutdownCopy.tag)
+			)
+
+			pprof.Do(shutdownGroupCtx, labels, func(ctx context.Context) {
+				err = g.runShutdownProcess(ctx, pprof.WithLabels(processCtx, labels), shutdownCopy, run)
+			})
+
+			return err
+		})
+	}
+
+	return shutdownGroup.Wait()
+}
+
+// runShutdownProcess run single shutdown process using process context and record the result.
+// it's waiting until the shutdown process is done or ctx is done, returned error will cancel other shutdown process.
+func (g *Graceful) runShutdownProcess(ctx, processCtx context.Context, s shutdown, run *shutdownRun) error {
+	var (
+		errChan   = make(chan error, 1)
+		startedAt = g.clock.Now()
+		recorder  = run.recorder
+	)
+
+	// shutdown process that is waiting for concurrency slot is not started when ctx is already done.
+	if ctx.Err() != nil {
+		recorder.skip([]shutdown{s}, SkipReasonContextCancelled)
+
+		return shutdownCtxErr(ctx)
+	}
+
+	if g.slowHookThreshold > 0 {
+		if deadline, ok := processCtx.Deadline(); ok {
+			var (
+				threshold = time.Duration(float64(deadline.Sub(startedAt)) * g.slowHookThreshold)
+				slow      = g.clock.After(threshold)
+				finished  = make(chan struct{})
+			)
+
+			defer close(finished)
+
+			go func() {
+				select {
+				case <-slow:
+					log.Warn().Fields(g.logFields).Str(shutdownTag, s.tag).Dur(hookElapsedTag, g.clock.Now().Sub(startedAt)).Msg(slowHookMessage)
+				case <-finished:
+				}
+			}()
+		}
+	}
+
+	g.emit(Event{Type: EventHookStarted, Tag: s.tag})
+
+	hookCtx, hook := g.startHook(processCtx, s.tag)
+	defer g.finishHook(s.tag, hook)
+
+	go func() {
+		errChan <- s.call(hookCtx, run)
+	}()
+
+	select {
+	case <-ctx.Done():
+		err := tagError(s.tag, shutdownCtxErr(ctx))
+		recorder.add(newHookReport(s, g.clock.Now().Sub(startedAt), err))
+		g.emit(Event{Type: EventHookFinished, Tag: s.tag, Err: err})
+		g.abandon(s, errChan, run)
+
+		// context cancelled by another failed shutdown process is not the failure of this one.
+		if errors.Is(err, ErrShutdownTimeout) {
+			run.record(s, err)
+		}
+
+		return err
+	case err := <-errChan:
+		duration := g.clock.Now().Sub(startedAt)
+		err = hookTimeoutError(hookCtx, hook.result(err))
+
+		switch {
+		case err != nil && run.errorLogs != nil:
+			run.errorLogs.add(s.tag, err)
+		case err != nil:
+			log.Error().Fields(g.logFields).Str(shutdownTag, s.tag).Dur(durationTag, duration).Err(err).Send()
+		default:
+			log.Info().Fields(g.logFields).Str(shutdownTag, s.tag).Dur(durationTag, duration).Msg(shutdownSuccessMessage)
+		}
+
+		err = tagError(s.tag, err)
+		recorder.add(newHookReport(s, duration, err))
+		g.emit(Event{Type: EventHookFinished, Tag: s.tag, Err: err})
+
+		// non fatal error is recorded in the report only, so it's never returned.
+		var nonFatal *nonFatalError
+		if errors.As(err, &nonFatal) {
+			err = nil
+		}
+
+		run.record(s, err)
+
+		// shutdown group error is contained to the group, so it never cancels other shutdown process.
+		if s.group == nil && isCancellationError(err, g.cancelOnError) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// tagError prefix shutdown process error with its tag, so the error message show which shutdown process is failed.
+func tagError(tag string, err error) error {
+	if err == nil {
+		return nil
+	}
+
+	return fmt.Errorf("%s: %w", tag, err)
+}
+
+// hookTimeoutError wrap deadline exceeded returned by shutdown process as hook timeout when its context deadline
+// is exceeded, so it's attributed to the deadline imposed on it instead of its own deadline.
+func hookTimeoutError(ctx context.Context, err error) error {
+	if errors.Is(err, context.DeadlineExceeded) && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+		return wrapSentinel(ErrHookTimeout, err)
+	}
+
+	return err
+}
+
+// shutdownCtxErr get error of done shutdown context, deadline exceeded is wrapped as shutdown timeout.
+func shutdownCtxErr(ctx context.Context) error {
+	err := ctx.Err()
+	if errors.Is(err, context.DeadlineExceeded) {
+		return wrapSentinel(ErrShutdownTimeout, err)
+	}
+
+	return err
+}
+
+// closeGates close all gates, so no new unit of work is accepted once shutdown is started.
+func (g *Graceful) closeGates() {
+	g.mutex.Lock()
+	gates := append([]*Gate(nil), g.gates...)
+	g.mutex.Unlock()
+
+	for _, gate := range gates {
+		gate.close()
+	}
+}
+
+// shutdownRun hold state of single shutdown process run.
+type shutdownRun struct {
+	recorder     *hookRecorder
+	errorLogs    *errorLogBuffer
+	reportAll    bool
+	softDeadline time.Time
+	active       int
+	peak         int
+	blocked      time.Duration
+	failed       []failedShutdown
+	retries      int
+	abandoned    int
+	results      map[string]interface{}
+	groups       map[string][]HookReport
+	mutex        sync.Mutex
+}
+
+// abandonedCount get number of shutdown process that is abandoned on this run.
+func (r *shutdownRun) abandonedCount() int {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.abandoned
+}
+
+// failedShutdown shutdown process and its last error.
+type failedShutdown struct {
+	shutdown shutdown
+	err      error
+}
+
+// record record result of shutdown process, it replaces the result of previous attempt.
+func (r *shutdownRun) record(s shutdown, err error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for i, failed := range r.failed {
+		if failed.shutdown.id == s.id {
+			r.failed = append(r.failed[:i], r.failed[i+1:]...)
+
+			break
+		}
+	}
+
+	if err != nil {
+		r.failed = append(r.failed, failedShutdown{shutdown: s, err: err})
+	}
+}
+
+// failedShutdowns get copy of failed shutdown process.
+func (r *shutdownRun) failedShutdowns() []shutdown {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	shutdowns := make([]shutdown, 0, len(r.failed))
+	for _, failed := range r.failed {
+		shutdowns = append(shutdowns, failed.shutdown)
+	}
+
+	return shutdowns
+}
+
+// result get fallback error, or all recorded shutdown process errors when report all errors is enabled.
+func (r *shutdownRun) result(fallback error) error {
+	if !r.reportAll {
+		return fallback
+	}
+
+	if err := r.failedErr(); err != nil {
+		return err
+	}
+
+	return fallback
+}
+
+// failedErr get all recorded shutdown process errors joined using errors.Join, single error is returned as is.
+func (r *shutdownRun) failedErr() error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	switch len(r.failed) {
+	case 0:
+		return nil
+	case 1:
+		return r.failed[0].err
+	default:
+		errs := make([]error, 0, len(r.failed))
+		for _, failed := range r.failed {
+			errs = append(errs, failed.err)
+		}
+
+		return errors.Join(errs...)
+	}
+}
+
+// enter record running shutdown process that got concurrency slot after waiting for blocked duration,
+// the returned function must be called when the shutdown process is done.
+func (r *shutdownRun) enter(blocked time.Duration) (leave func()) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.active++
+	r.blocked += blocked
+
+	if r.active > r.peak {
+		r.peak = r.active
+	}
+
+	return func() {
+		r.mutex.Lock()
+		defer r.mutex.Unlock()
+
+		r.active--
+	}
+}
+
+// concurrency get max observed concurrent shutdown process and total time blocked waiting for concurrency slot.
+func (r *shutdownRun) concurrency() (peak int, blocked time.Duration) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.peak, r.blocked
+}
+
+// settledNumGoroutine get goroutine count after giving the package own shutdown goroutines
+// some time to exit, so they're not counted as leak.
+func settledNumGoroutine(baseline int) int {
+	count := runtime.NumGoroutine()
+
+	for i := 0; i < 10 && count > baseline; i++ {
+		time.Sleep(time.Millisecond)
+		count = runtime.NumGoroutine()
+	}
+
+	return count
+}
+
+// waitSignalJitter wait random duration up to signal jitter when shutdown is triggered by os signal.
+// the waiting is stopped when got another os signal.
+func (g *Graceful) waitSignalJitter() {
+	if g.signalJitter < 1 || g.signalWatcher == nil || g.signalWatcher.firstSignal() == nil {
+		return
+	}
+
+	var (
+		random  = rand.New(rand.NewSource(time.Now().UnixNano()))
+		jitter  = g.clock.After(time.Duration(random.Int63n(int64(g.signalJitter) + 1)))
+		sigChan = make(chan os.Signal, 1)
+	)
+
+	// notify without signals will relay all incoming signals.
+	if len(g.signals) > 0 {
+		signal.Notify(sigChan, g.signals...)
+		defer signal.Stop(sigChan)
+	}
+
+	select {
+	case <-jitter:
+	case <-sigChan:
+	}
+}
+
+// GroupContext get context of the background process group, the same context that is passed to RegisterProcessWithContext.
+// it's cancelled on every shutdown trigger, including error from background process, unlike the signal context
+// of RegisterProcessWithSignalContext that is not cancelled right away by background process error.
+// it must be used read only, e.g. to derive child context without registering background process.
+func (g *Graceful) GroupContext() context.Context {
+	return g.groupCtx
+}
+
+// PostShutdownContext get context that is cancelled only after all shutdown process is done.
+// it's not derived from the parent context, so it stays alive during shutdown process and
+// it's cancelled right before Wait returns, after shutdown process is finished or timed out,
+// or once DrainNow is done when Wait is not called. it's never cancelled when neither of them is called.
+func (g *Graceful) PostShutdownContext() context.Context {
+	return g.postShutdownCtx
+}
+
+// Wait waiting for os signal send and call shutdown process when got some signal.
+// calling Wait more than once will return ErrAlreadyWaiting.
+func (g *Graceful) Wait() error {
+	g.mutex.Lock()
+	if g.state != stateIdle {
+		g.mutex.Unlock()
+
+		return ErrAlreadyWaiting
+	}
+
+	if g.entryPoint == "" {
+		g.setEntryPoint(EntryPointWait)
+	}
+
+	g.state = stateWaiting
+	g.mutex.Unlock()
+
+	g.alignTerminationGracePeriod()
+
+	if err := g.runPreflights(); err != nil {
+		g.runFinalizers()
+		g.finish(err, OutcomeProcessError)
+
+		return err
+	}
+
+	g.runCallbacks(&g.onStart)
+	g.emit(Event{Type: EventStarted})
+	g.checkComplete()
+
+	// the shutdown watcher is run outside the group, so it never takes a slot of process concurrency.
+	watcherErr := make(chan error, 1)
+
+	go func() {
+		<-g.groupCtx.Done()
+
+		var err error
+
+		// the result is sent only after the finalizers are done, so they're done before Wait returns.
+		defer func() {
+			g.runFinalizers()
+			watcherErr <- err
+		}()
+
+		g.waitSignalJitter()
+		g.setState(stateShuttingDown)
+		g.runCallbacks(&g.onShutdownStart)
+
+		// signal context is not done means the group is cancelled by background process error,
+		// it's kept when the shutdown process is skipped, otherwise shutdownOnce cancel it before running it.
+		if g.signalCtx.Err() == nil && !g.shutdownOnError {
+			return
+		}
+
+		err = g.shutdownOnce()
+	}()
+
+	// the group is waited only after it's cancelled, since Wait of the group cancel it once all processes are returned.
+	<-g.groupCtx.Done()
+
+	// the returned cancel cause is kept only when shutdown process is succeeded, see SetReturnCancelCause.
+	var (
+		err         = g.group.Wait()
+		shutdownErr = <-watcherErr
+		outcome     = OutcomeProcessError
+	)
+
+	switch {
+	case err == nil || (shutdownErr != nil && g.isCancelCause(err)):
+		err, outcome = shutdownErr, shutdownOutcome(shutdownErr)
+	case g.isCancelCause(err):
+		outcome = OutcomeClean
+	}
+
+	g.finish(err, outcome)
+
+	return err
+}
+
+// finish mark the lifecycle as done with the final result and its outcome after shutdown process is done.
+func (g *Graceful) finish(err error, outcome Outcome) {
+	g.postShutdownCancel()
+	g.stopSignal()
+
+	g.mutex.Lock()
+	g.state = stateDone
+	g.waitErr = err
+	g.lastOutcome = outcome
+	close(g.done)
+	notifies := append([]chan<- error(nil), g.doneNotifies...)
+	g.mutex.Unlock()
+
+	notifyDone(notifies, err)
+
+	g.closeEvents(err)
+}
+
+// DrainNow run shutdown process right away and return its result, or ctx error when ctx is done first.
+// it's the entry point when the host app manages its own lifecycle and never call Wait.
+// when Wait is running, it triggers Wait to stop the background processes and shares the same shutdown process result,
+// otherwise it also run the finalizers and mark graceful as done, so Wait will return ErrAlreadyWaiting.
+func (g *Graceful) DrainNow(ctx context.Context) error {
+	g.mutex.Lock()
+	owner := g.state == stateIdle
+	g.setEntryPoint(EntryPointDrainNow)
+	if g.state < stateShuttingDown {
+		g.state = stateShuttingDown
+	}
+	g.mutex.Unlock()
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		err := g.shutdownOnce()
+
+		if owner {
+			g.runFinalizers()
+			g.finish(err, shutdownOutcome(err))
+		}
+
+		errChan <- err
+	}()
+
+	select {
+	case err := <-errChan:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// IsShuttingDown check whether shutdown process is running.
+func (g *Graceful) IsShuttingDown() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.state == stateShuttingDown
+}
+
+// Stop trigger shutdown process without os signal and block until it's done or ctx is done.
+// when Wait is not called yet, Stop call it, so shutdown process is run right away.
+// it's safe to call more than once and all of them return the same Wait result.
+func (g *Graceful) Stop(ctx context.Context) error {
+	g.mutex.Lock()
+	g.setEntryPoint(EntryPointStop)
+	g.mutex.Unlock()
+
+	g.trigger()
+
+	g.mutex.Lock()
+	idle := g.state == stateIdle
+	g.mutex.Unlock()
+
+	if idle {
+		go func() {
+			_ = g.Wait()
+		}()
+	}
+
+	select {
+	case <-g.done:
+		g.mutex.Lock()
+		defer g.mutex.Unlock()
+
+		return g.waitErr
+	case <-ctx.Done():
+		return ctx.Err()
+	}
 }
@@ -1,14 +1,22 @@
 package graceful
 
 import (
+	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"os"
+	"runtime"
+	"runtime/pprof"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"syscall"
 	"testing"
 	"time"
 
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -21,7 +29,7 @@ func TestGraceful_Run(t *testing.T) {
 	graceful.RegisterProcessWithContext(nil)
 	graceful.RegisterShutdownProcess(nil)
 
-	graceful.SetMaxShutdownTime(1 * time.Second)
+	graceful.SetMaxShutdownTime(3 * time.Second)
 	graceful.SetMaxShutdownProcess(1)
 	graceful.SetCancelOnError(false)
 
@@ -106,6 +114,1482 @@ func TestGraceful_CancelOnError(t *testing.T) {
 	assert.NotNil(t, err)
 }
 
+func TestGraceful_SetIDGenerator(t *testing.T) {
+	graceful := New()
+
+	id := graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+	assert.Len(t, id, 32)
+
+	graceful.SetIDGenerator(func() string {
+		return "custom-id"
+	})
+
+	id = graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+	assert.Equal(t, "custom-id", id)
+
+	graceful.SetIDGenerator(nil)
+
+	id = graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+	assert.Len(t, id, 32)
+}
+
+func TestGraceful_LastShutdownReport(t *testing.T) {
+	graceful := New()
+
+	assert.Equal(t, 0, graceful.EffectiveShutdownConcurrency())
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "first")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errors.New("err")
+	}, "second")
+
+	assert.Equal(t, 2, graceful.EffectiveShutdownConcurrency())
+
+	graceful.SetMaxShutdownProcess(1)
+	assert.Equal(t, 1, graceful.EffectiveShutdownConcurrency())
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+	assert.Nil(t, err)
+
+	report := graceful.LastShutdownReport()
+	assert.Equal(t, 1, report.EffectiveConcurrency)
+	assert.Len(t, report.Hooks, 2)
+	assert.False(t, report.StartedAt.IsZero())
+	assert.Equal(t, "first", report.Hooks[0].Tag)
+	assert.Equal(t, "second", report.Hooks[1].Tag)
+	assert.EqualError(t, report.Hooks[1].Err, "second: err")
+}
+
+func TestGraceful_ShutdownErrorTag(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+
+	expectedErr := errors.New("close failed")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return expectedErr
+	}, "http-server")
+
+	err := graceful.DrainNow(context.Background())
+
+	assert.EqualError(t, err, "http-server: close failed")
+	assert.ErrorIs(t, err, expectedErr)
+	assert.Equal(t, expectedErr, errors.Unwrap(err))
+}
+
+func TestGraceful_LastShutdownReportConcurrency(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownProcess(2)
+
+	for i := 0; i < 4; i++ {
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			time.Sleep(100 * time.Millisecond)
+			return nil
+		})
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	report := graceful.LastShutdownReport()
+	assert.Equal(t, 2, report.PeakConcurrency)
+	// the last two shutdown process wait for the first two to finish.
+	assert.GreaterOrEqual(t, report.BlockedTime, 200*time.Millisecond)
+	assert.Less(t, report.BlockedTime, time.Second)
+}
+
+func TestGraceful_SetWarnConcurrencySaturation(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetMaxShutdownProcess(1)
+	graceful.SetWarnConcurrencySaturation(true)
+
+	for i := 0; i < 5; i++ {
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			time.Sleep(20 * time.Millisecond)
+			return nil
+		})
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	report := graceful.LastShutdownReport()
+	assert.True(t, report.ConcurrencySaturated)
+	assert.Equal(t, 1, strings.Count(logs.String(), concurrencySaturatedMessage))
+	assert.Contains(t, logs.String(), `"hook-count":5,"max-shutdown-process":1`)
+}
+
+func TestConcurrencySaturated(t *testing.T) {
+	assert.True(t, concurrencySaturated(5, 2, 2*time.Second, time.Second))
+	assert.False(t, concurrencySaturated(4, 2, 2*time.Second, time.Second))
+	assert.False(t, concurrencySaturated(5, 2, time.Second, 2*time.Second))
+	assert.False(t, concurrencySaturated(5, 0, 2*time.Second, time.Second))
+}
+
+func TestGraceful_SetStrictNil(t *testing.T) {
+	SetStrictNil(true)
+	defer SetStrictNil(false)
+
+	graceful := New()
+
+	assert.PanicsWithError(t, "graceful: nil process: RegisterProcess", func() {
+		graceful.RegisterProcess(nil)
+	})
+	assert.PanicsWithError(t, "graceful: nil process: RegisterProcessWithContext", func() {
+		graceful.RegisterProcessWithContext(nil)
+	})
+	assert.PanicsWithError(t, "graceful: nil process: RegisterShutdownProcess", func() {
+		graceful.RegisterShutdownProcess(nil)
+	})
+	assert.PanicsWithError(t, "graceful: nil process: RegisterShutdownProcessWithTag", func() {
+		graceful.RegisterShutdownProcessWithTag(nil, "tag")
+	})
+
+	SetStrictNil(false)
+
+	assert.NotPanics(t, func() {
+		graceful.RegisterProcess(nil)
+	})
+}
+
+func TestGraceful_PostShutdownContext(t *testing.T) {
+	graceful := New()
+	postShutdownCtx := graceful.PostShutdownContext()
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		assert.Nil(t, postShutdownCtx.Err())
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	assert.Nil(t, postShutdownCtx.Err())
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.ErrorIs(t, postShutdownCtx.Err(), context.Canceled)
+
+	drained := NewFromContext(context.Background())
+	postShutdownCtx = drained.PostShutdownContext()
+
+	assert.Nil(t, drained.DrainNow(context.Background()))
+	assert.ErrorIs(t, postShutdownCtx.Err(), context.Canceled)
+}
+
+func TestGraceful_SetSignalJitter(t *testing.T) {
+	graceful := New()
+	graceful.SetSignalJitter(time.Hour)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	startedAt := time.Now()
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Less(t, time.Since(startedAt), 5*time.Second)
+}
+
+func TestGraceful_SetLabelGoroutines(t *testing.T) {
+	graceful := New()
+	graceful.SetLabelGoroutines(true)
+
+	var label string
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		label, _ = pprof.Label(ctx, "graceful-hook")
+		return nil
+	}, "labeled")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Equal(t, "labeled", label)
+}
+
+func TestGraceful_SetLeakDetection(t *testing.T) {
+	graceful := New()
+	graceful.SetLeakDetection(true)
+
+	stop := make(chan struct{})
+	defer close(stop)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		go func() {
+			<-stop
+		}()
+
+		return nil
+	})
+
+	// keep signal sender alive, so it's not counted on goroutine delta.
+	go func() {
+		sendSignal(syscall.SIGTERM)
+		<-stop
+	}()
+
+	err := graceful.Wait()
+	report := graceful.LastShutdownReport()
+
+	assert.Nil(t, err)
+	assert.Greater(t, report.GoroutinesBefore, 0)
+	assert.Equal(t, 1, report.GoroutineDelta)
+}
+
+func TestGraceful_RegisterShutdownProcessOnShutdown(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownWaves(3)
+
+	var (
+		mx    = &sync.Mutex{}
+		procs = make([]string, 0)
+		hook  func(ctx context.Context) error
+	)
+
+	hook = func(ctx context.Context) error {
+		mx.Lock()
+		defer mx.Unlock()
+
+		procs = append(procs, "hook")
+		graceful.RegisterShutdownProcess(hook)
+
+		return nil
+	}
+
+	graceful.RegisterShutdownProcess(hook)
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Len(t, procs, 3)
+	assert.Len(t, graceful.LastShutdownReport().Hooks, 3)
+}
+
+func TestGraceful_Errors(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+
+	id := graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		time.Sleep(1 * time.Second)
+		return nil
+	}, "slow")
+	assert.NotEmpty(t, id)
+
+	id = graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "slow")
+	assert.NotEmpty(t, id)
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		assert.ErrorIs(t, graceful.Wait(), ErrAlreadyWaiting)
+	}()
+
+	err := graceful.Wait()
+
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorIs(t, graceful.Wait(), ErrAlreadyWaiting)
+
+	id = graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+	assert.Empty(t, id)
+}
+
+func TestGraceful_SetSoftShutdownTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(5 * time.Second)
+	graceful.SetSoftShutdownTimeout(100 * time.Millisecond)
+
+	var stopped bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(100 * time.Millisecond)
+		stopped = true
+
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	startedAt := time.Now()
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.True(t, stopped)
+	assert.Less(t, time.Since(startedAt), 2*time.Second)
+}
+
+func TestGraceful_HookTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+	graceful.SetMaxShutdownTime(5 * time.Second)
+	graceful.SetSoftShutdownTimeout(50 * time.Millisecond)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, "imposed")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		ctx, cancel := context.WithTimeout(ctx, time.Millisecond)
+		defer cancel()
+
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, "genuine")
+
+	err := graceful.Stop(context.Background())
+	assert.ErrorIs(t, err, ErrHookTimeout)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	errs := map[string]error{}
+	for _, hook := range graceful.LastShutdownReport().Hooks {
+		errs[hook.Tag] = hook.Err
+	}
+
+	assert.ErrorIs(t, errs["imposed"], ErrHookTimeout)
+	assert.ErrorIs(t, errs["imposed"], context.DeadlineExceeded)
+	assert.Equal(t, "imposed: graceful: hook timeout: context deadline exceeded", errs["imposed"].Error())
+
+	assert.NotErrorIs(t, errs["genuine"], ErrHookTimeout)
+	assert.ErrorIs(t, errs["genuine"], context.DeadlineExceeded)
+}
+
+func TestGraceful_SetSlowHookThreshold(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetMaxShutdownTime(time.Second)
+	graceful.SetSoftShutdownTimeout(400 * time.Millisecond)
+	graceful.SetSlowHookThreshold(0.5)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		time.Sleep(300 * time.Millisecond)
+
+		return nil
+	}, "slow")
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "fast")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, strings.Count(logs.String(), slowHookMessage))
+	assert.Contains(t, logs.String(), `"graceful-shutdown-tag":"slow","elapsed"`)
+}
+
+func TestGraceful_SetShutdownProfile(t *testing.T) {
+	var profile, failedProfile bytes.Buffer
+
+	graceful := New()
+	graceful.SetShutdownProfile(&profile)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		// profile is already started, so the other profile is failed to start.
+		failed := New()
+		failed.SetShutdownProfile(&failedProfile)
+		failed.RegisterShutdownProcess(func(ctx context.Context) error {
+			return nil
+		})
+
+		return failed.Stop(ctx)
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.NotZero(t, profile.Len())
+	assert.Zero(t, failedProfile.Len())
+}
+
+func TestGraceful_NewGate(t *testing.T) {
+	graceful := New()
+	gate := graceful.NewGate()
+
+	leave, ok := gate.Enter()
+	assert.True(t, ok)
+
+	var drained bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		_, ok := gate.Enter()
+		assert.False(t, ok)
+
+		time.Sleep(100 * time.Millisecond)
+		drained = true
+		leave()
+
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.True(t, drained)
+	assert.Len(t, graceful.LastShutdownReport().Hooks, 2)
+	assert.Equal(t, "", graceful.LastShutdownReport().Hooks[1].Error)
+}
+
+func TestGraceful_SetInflightDrainCallback(t *testing.T) {
+	graceful := New()
+	gate := graceful.NewGate()
+
+	var (
+		remainings []int
+		leaves     []func()
+	)
+
+	graceful.SetInflightDrainCallback(func(remaining int) {
+		remainings = append(remainings, remaining)
+	})
+
+	for i := 0; i < 3; i++ {
+		leave, ok := gate.Enter()
+		assert.True(t, ok)
+
+		leaves = append(leaves, leave)
+	}
+
+	// leaving before shutdown is not reported.
+	leaves[0]()
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		for _, leave := range leaves[1:] {
+			go leave()
+		}
+
+		return nil
+	})
+
+	err := graceful.Stop(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, []int{1, 0}, remainings)
+}
+
+func TestGraceful_SetRunShutdownOnProcessError(t *testing.T) {
+	for _, value := range []bool{true, false} {
+		graceful := New()
+		graceful.SetRunShutdownOnProcessError(value)
+
+		var shutdownCalled bool
+
+		graceful.RegisterProcess(func() error {
+			return errors.New("process err")
+		})
+
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			shutdownCalled = true
+			return nil
+		})
+
+		err := graceful.Wait()
+
+		assert.EqualError(t, err, "process err")
+		assert.Equal(t, value, shutdownCalled)
+	}
+}
+
+func TestGraceful_SetReturnCancelCause(t *testing.T) {
+	for _, value := range []bool{true, false} {
+		ctx, cancel := context.WithCancel(context.Background())
+		graceful := NewFromContext(ctx)
+		graceful.SetReturnCancelCause(value)
+
+		graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+
+		cancel()
+
+		err := graceful.Wait()
+
+		if value {
+			assert.ErrorIs(t, err, context.Canceled)
+		} else {
+			assert.Nil(t, err)
+		}
+
+		assert.Equal(t, value, graceful.Config().ReturnCancelCause)
+	}
+}
+
+func TestGraceful_SetReturnCancelCauseShutdownError(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	graceful := NewFromContext(ctx)
+	graceful.SetReportAllErrors(true)
+	graceful.SetReturnCancelCause(true)
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return errors.New("shutdown err")
+	})
+
+	cancel()
+
+	err := graceful.Wait()
+
+	assert.ErrorContains(t, err, "shutdown err")
+	assert.NotErrorIs(t, err, context.Canceled)
+}
+
+func TestDefaultSignals(t *testing.T) {
+	signals := DefaultSignals()
+	assert.Equal(t, defaultSignals, signals)
+
+	signals[0] = syscall.SIGQUIT
+	assert.NotEqual(t, defaultSignals, signals)
+}
+
+func TestGraceful_SetPhaseConcurrency(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownPhases("ingress", "storage")
+	graceful.SetPhaseConcurrency("storage", 1)
+
+	var (
+		mx            = &sync.Mutex{}
+		procs         = make([]string, 0)
+		running, peak int
+	)
+
+	track := func(tag string) func(ctx context.Context) error {
+		return func(ctx context.Context) error {
+			mx.Lock()
+			running++
+			if running > peak {
+				peak = running
+			}
+			procs = append(procs, tag)
+			mx.Unlock()
+
+			time.Sleep(50 * time.Millisecond)
+
+			mx.Lock()
+			running--
+			mx.Unlock()
+
+			return nil
+		}
+	}
+
+	graceful.RegisterShutdownProcessWithPhase(track("disk-1"), "disk-1", "storage")
+	graceful.RegisterShutdownProcessWithPhase(track("disk-2"), "disk-2", "storage")
+	graceful.RegisterShutdownProcessWithPhase(track("disk-3"), "disk-3", "storage")
+	graceful.RegisterShutdownProcessWithPhase(track("http"), "http", "ingress")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Equal(t, 1, peak)
+	assert.Equal(t, []string{"http", "disk-1", "disk-2", "disk-3"}, procs)
+}
+
+func TestGraceful_RegisterProcessIgnoringErrors(t *testing.T) {
+	graceful := New()
+
+	var (
+		errServerClosed = errors.New("server closed")
+		expectedErr     = errors.New("listen failed")
+	)
+
+	graceful.RegisterProcessIgnoringErrors(func() error {
+		return fmt.Errorf("serve: %w", errServerClosed)
+	}, errServerClosed)
+
+	graceful.RegisterProcessIgnoringErrors(func() error {
+		time.Sleep(100 * time.Millisecond)
+
+		return expectedErr
+	}, errServerClosed)
+
+	graceful.RegisterProcessIgnoringErrors(nil)
+
+	err := graceful.Wait()
+
+	assert.Equal(t, expectedErr, err)
+}
+
+func TestGraceful_RegisterProcessWithSignalContext(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+
+	var (
+		expectedErr = errors.New("process err")
+		started     = make(chan context.Context, 1)
+		running     bool
+		shutdown    bool
+	)
+
+	graceful.RegisterProcessWithSignalContext(func(ctx context.Context) error {
+		started <- ctx
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	signalCtx := <-started
+
+	graceful.RegisterProcess(func() error {
+		return expectedErr
+	})
+
+	// the group context is already cancelled by the failed process when shutdown is started.
+	graceful.OnShutdownStart(func() {
+		running = signalCtx.Err() == nil && graceful.GroupContext().Err() != nil
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdown = true
+
+		return nil
+	})
+
+	err := graceful.Wait()
+
+	assert.ErrorIs(t, err, expectedErr)
+	assert.True(t, running)
+	assert.True(t, shutdown)
+	assert.NotNil(t, signalCtx.Err())
+}
+
+func TestGraceful_RegisterProcessWithSignalContextShutdownSkipped(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+	graceful.SetCancelOnError(false)
+	graceful.SetRunShutdownOnProcessError(false)
+
+	var (
+		expectedErr = errors.New("process err")
+		started     = make(chan context.Context, 1)
+		finalized   = make(chan struct{})
+		errChan     = make(chan error, 1)
+	)
+
+	graceful.RegisterProcessWithSignalContext(func(ctx context.Context) error {
+		started <- ctx
+		<-ctx.Done()
+
+		return nil
+	})
+
+	signalCtx := <-started
+
+	// the finalizers are run once the skipped shutdown is handled by the watcher.
+	graceful.RegisterFinalizer(func() {
+		close(finalized)
+	})
+
+	graceful.RegisterProcess(func() error {
+		return expectedErr
+	})
+
+	go func() {
+		errChan <- graceful.Wait()
+	}()
+
+	<-finalized
+
+	assert.NotNil(t, graceful.GroupContext().Err())
+	assert.Nil(t, signalCtx.Err())
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), expectedErr)
+	assert.ErrorIs(t, <-errChan, expectedErr)
+	assert.NotNil(t, signalCtx.Err())
+}
+
+func TestGraceful_ParentContextDeadline(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	graceful := NewWithContext(ctx)
+	graceful.SetMaxShutdownTime(5 * time.Second)
+
+	deadlines := make(chan time.Time, 1)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		deadline, _ := ctx.Deadline()
+		deadlines <- deadline
+		<-ctx.Done()
+
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	startedAt := time.Now()
+	err := graceful.Wait()
+
+	parentDeadline, _ := ctx.Deadline()
+
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	// the parent deadline is applied using the clock timeout, so it's off by the time to apply it.
+	assert.WithinDuration(t, parentDeadline, <-deadlines, 50*time.Millisecond)
+	assert.Less(t, time.Since(startedAt), time.Second)
+}
+
+func TestGraceful_NewWithContextE(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	graceful, err := NewWithContextE(ctx)
+	assert.Nil(t, err)
+	assert.NotNil(t, graceful)
+
+	cancel()
+
+	graceful, err = NewWithContextE(ctx)
+	assert.Nil(t, graceful)
+	assert.ErrorIs(t, err, ErrContextDone)
+	assert.ErrorIs(t, err, context.Canceled)
+}
+
+func TestGraceful_NewWithContextDone(t *testing.T) {
+	logs := captureLogs(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	graceful := NewWithContext(ctx)
+
+	var shutdownCalled bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+		return nil
+	})
+
+	assert.Nil(t, graceful.Wait())
+	assert.True(t, shutdownCalled)
+	assert.Contains(t, logs.String(), contextDoneMessage)
+}
+
+func TestGraceful_NewFromContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	graceful := NewFromContext(ctx)
+
+	var shutdownCalled bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+		return nil
+	})
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.True(t, shutdownCalled)
+}
+
+func TestGraceful_LastShutdownReportSkipped(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+	graceful.SetShutdownPhases("first", "second")
+	graceful.SetPhaseConcurrency("first", 1)
+
+	graceful.RegisterShutdownProcessWithPhase(func(ctx context.Context) error {
+		return errors.New("err")
+	}, "failed", "first")
+
+	graceful.RegisterShutdownProcessWithPhase(func(ctx context.Context) error {
+		return nil
+	}, "cancelled", "first")
+
+	graceful.RegisterShutdownProcessWithPhase(func(ctx context.Context) error {
+		return nil
+	}, "aborted", "second")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.EqualError(t, err, "failed: err")
+	assert.Equal(t, []SkippedHook{
+		{ID: graceful.LastShutdownReport().Skipped[0].ID, Tag: "cancelled", Reason: SkipReasonContextCancelled},
+		{ID: graceful.LastShutdownReport().Skipped[1].ID, Tag: "aborted", Reason: SkipReasonAborted},
+	}, graceful.LastShutdownReport().Skipped)
+}
+
+func TestGraceful_SignalCounts(t *testing.T) {
+	graceful := New()
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		sendSignal(syscall.SIGINT)
+		sendSignal(syscall.SIGINT)
+
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[os.Signal]int{syscall.SIGTERM: 1, syscall.SIGINT: 2}, graceful.SignalCounts())
+	assert.Equal(t, map[string]int{"terminated": 1, "interrupt": 2}, graceful.LastShutdownReport().SignalCounts)
+}
+
+func TestGraceful_Stop(t *testing.T) {
+	graceful := New()
+
+	var shutdownCalled int
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		shutdownCalled++
+		return errors.New("err")
+	}, "stop")
+
+	graceful.SetCancelOnError(true)
+
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- graceful.Wait()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.EqualError(t, graceful.Stop(context.Background()), "stop: err")
+	assert.EqualError(t, graceful.Stop(context.Background()), "stop: err")
+	assert.EqualError(t, <-errChan, "stop: err")
+	assert.Equal(t, 1, shutdownCalled)
+}
+
+func TestGraceful_StopWithoutWait(t *testing.T) {
+	graceful := New()
+	graceful.SetSignalJitter(time.Hour)
+
+	var shutdownCalled bool
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+		return nil
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.True(t, shutdownCalled)
+	assert.ErrorIs(t, graceful.Wait(), ErrAlreadyWaiting)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	blocked := New()
+	blocked.RegisterShutdownProcess(func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+
+	assert.ErrorIs(t, blocked.Stop(ctx), context.Canceled)
+	assert.Nil(t, blocked.Stop(context.Background()))
+}
+
+func TestGraceful_RegisterFinalizer(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+
+	procs := make([]string, 0)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		time.Sleep(1 * time.Second)
+		return nil
+	})
+
+	graceful.RegisterFinalizer(func() {
+		procs = append(procs, "first")
+		panic("finalizer panic")
+	})
+
+	graceful.RegisterFinalizer(func() {
+		procs = append(procs, "second")
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Equal(t, []string{"first", "second"}, procs)
+}
+
+func TestGraceful_RegisterFinalizerBeforeWaitReturns(t *testing.T) {
+	for _, shutdownOnError := range []bool{true, false} {
+		graceful := NewFromContext(context.Background())
+		graceful.SetRunShutdownOnProcessError(shutdownOnError)
+
+		var finalized int32
+
+		graceful.RegisterFinalizer(func() {
+			time.Sleep(50 * time.Millisecond)
+			atomic.StoreInt32(&finalized, 1)
+		})
+
+		graceful.RegisterProcess(func() error {
+			return errors.New("crashed")
+		})
+
+		assert.Error(t, graceful.Wait())
+		assert.Equal(t, int32(1), atomic.LoadInt32(&finalized), "finalizer is still running when Wait returns")
+	}
+}
+
+func TestGraceful_Lifecycle(t *testing.T) {
+	graceful := New()
+	procs := make([]string, 0)
+
+	graceful.OnStart(func() {
+		procs = append(procs, "start")
+	})
+
+	graceful.OnShutdownStart(func() {
+		procs = append(procs, "shutdown-start")
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		procs = append(procs, "shutdown")
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"start", "shutdown-start", "shutdown"}, procs)
+}
+
+func TestGraceful_ShutdownOnce(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	graceful := NewWithContext(ctx)
+
+	var (
+		mx             = &sync.Mutex{}
+		shutdownCalled int
+	)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		mx.Lock()
+		defer mx.Unlock()
+
+		shutdownCalled++
+
+		return nil
+	})
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		cancel()
+	}()
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	go func() {
+		time.Sleep(100 * time.Millisecond)
+		_ = graceful.Stop(context.Background())
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Nil(t, graceful.shutdownOnce())
+	assert.Equal(t, 1, shutdownCalled)
+}
+
+func TestGraceful_DrainNow(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+
+	var (
+		shutdownCalled  int
+		finalizerCalled bool
+		expectedErr     = errors.New("close failed")
+	)
+
+	graceful.RegisterFinalizer(func() {
+		finalizerCalled = true
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled++
+		assert.True(t, graceful.IsShuttingDown())
+
+		return expectedErr
+	})
+
+	assert.False(t, graceful.IsShuttingDown())
+
+	err := graceful.DrainNow(context.Background())
+
+	assert.ErrorIs(t, err, expectedErr)
+	assert.False(t, graceful.IsShuttingDown())
+	assert.True(t, finalizerCalled)
+	assert.ErrorIs(t, graceful.DrainNow(context.Background()), expectedErr)
+	assert.ErrorIs(t, graceful.Stop(context.Background()), expectedErr)
+	assert.ErrorIs(t, graceful.Wait(), ErrAlreadyWaiting)
+	assert.Equal(t, 1, shutdownCalled)
+}
+
+func TestGraceful_DrainNowContextDone(t *testing.T) {
+	graceful := New()
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		time.Sleep(200 * time.Millisecond)
+
+		return nil
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	assert.ErrorIs(t, graceful.DrainNow(ctx), context.DeadlineExceeded)
+	assert.True(t, graceful.IsShuttingDown())
+	assert.Nil(t, graceful.Stop(context.Background()))
+}
+
+func TestGraceful_DrainNowWaitRunning(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+	graceful.SetReportAllErrors(true)
+
+	var (
+		started        = make(chan struct{})
+		shutdownCalled int32
+		expectedErr    = errors.New("close failed")
+	)
+
+	graceful.OnStart(func() {
+		close(started)
+	})
+	graceful.RegisterProcessWithSignalContext(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return nil
+	})
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		atomic.AddInt32(&shutdownCalled, 1)
+
+		return expectedErr
+	})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- graceful.Wait()
+	}()
+
+	<-started
+
+	assert.ErrorIs(t, graceful.DrainNow(context.Background()), expectedErr)
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, expectedErr)
+	case <-time.After(time.Second):
+		t.Fatal("Wait is not returned after DrainNow")
+	}
+
+	assert.False(t, graceful.IsShuttingDown())
+	assert.Equal(t, EntryPointDrainNow, graceful.LastShutdownReport().EntryPoint)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&shutdownCalled))
+}
+
+func TestGraceful_Reset(t *testing.T) {
+	graceful := New()
+	gate := graceful.NewGate()
+
+	var shutdownCalled int
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled++
+		return nil
+	})
+
+	for i := 0; i < 2; i++ {
+		var processStopped bool
+
+		graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+			<-ctx.Done()
+			processStopped = true
+
+			return nil
+		})
+
+		leave, ok := gate.Enter()
+		assert.True(t, ok)
+		leave()
+
+		go func() {
+			sendSignal(syscall.SIGTERM)
+		}()
+
+		err := graceful.Wait()
+
+		assert.Nil(t, err)
+		assert.True(t, processStopped)
+		assert.Equal(t, i+1, shutdownCalled)
+		assert.Equal(t, 1, graceful.SignalCounts()[syscall.SIGTERM])
+		assert.True(t, graceful.SignalLoopStopped())
+		assert.Nil(t, graceful.Reset())
+	}
+}
+
+func TestGraceful_ResetWhileWaiting(t *testing.T) {
+	graceful := New()
+
+	go func() {
+		_ = graceful.Wait()
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.ErrorIs(t, graceful.Reset(), ErrAlreadyWaiting)
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Nil(t, graceful.Reset())
+	assert.Nil(t, graceful.Reset())
+	assert.Nil(t, graceful.Stop(context.Background()))
+}
+
+func TestGraceful_ShutdownStartedAt(t *testing.T) {
+	graceful := New()
+
+	_, started := graceful.ShutdownStartedAt()
+	assert.False(t, started)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		_, started := graceful.ShutdownStartedAt()
+		assert.True(t, started)
+
+		return nil
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	startedAt, started := graceful.ShutdownStartedAt()
+	assert.True(t, started)
+	assert.Equal(t, startedAt, graceful.LastShutdownReport().StartedAt)
+}
+
+func TestGraceful_ErrStopRequested(t *testing.T) {
+	graceful := New()
+
+	var shutdownCalled bool
+
+	graceful.RegisterProcess(func() error {
+		return fmt.Errorf("batch done: %w", ErrStopRequested)
+	})
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+		return nil
+	})
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.True(t, shutdownCalled)
+}
+
+func TestGraceful_SetShutdownVeto(t *testing.T) {
+	graceful := New()
+
+	var (
+		vetoCalled     int32
+		shutdownCalled bool
+	)
+
+	graceful.SetShutdownVeto(func(sig os.Signal) bool {
+		return atomic.AddInt32(&vetoCalled, 1) > 1
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+		return nil
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.True(t, shutdownCalled)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&vetoCalled))
+	assert.Equal(t, 2, graceful.SignalCounts()[syscall.SIGTERM])
+}
+
+func TestGraceful_SetShutdownVetoBypass(t *testing.T) {
+	graceful := New()
+
+	release := make(chan struct{})
+	defer close(release)
+
+	graceful.SetShutdownVeto(func(sig os.Signal) bool {
+		<-release
+		return false
+	})
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+		sendSignal(syscall.SIGINT)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.Equal(t, map[os.Signal]int{syscall.SIGTERM: 1, syscall.SIGINT: 1}, graceful.SignalCounts())
+}
+
+func TestGraceful_SignalLoopStopped(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	for i := 0; i < 20; i++ {
+		graceful := New()
+
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			return nil
+		})
+
+		assert.False(t, graceful.SignalLoopStopped())
+		assert.Nil(t, graceful.Stop(context.Background()))
+		assert.True(t, graceful.SignalLoopStopped())
+	}
+
+	assert.LessOrEqual(t, settledNumGoroutine(before), before)
+}
+
+func TestGraceful_ProcessCancellation(t *testing.T) {
+	triggers := map[string]func(g *Graceful) func() error{
+		"process error": func(g *Graceful) func() error {
+			return func() error {
+				return errors.New("process err")
+			}
+		},
+		"stop requested": func(g *Graceful) func() error {
+			return func() error {
+				return ErrStopRequested
+			}
+		},
+		"stop": func(g *Graceful) func() error {
+			return func() error {
+				go func() {
+					_ = g.Stop(context.Background())
+				}()
+
+				return nil
+			}
+		},
+	}
+
+	for name, trigger := range triggers {
+		graceful := New()
+		cancelled := make(chan time.Time, 1)
+
+		graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+			<-ctx.Done()
+			cancelled <- time.Now()
+
+			return nil
+		})
+
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			return nil
+		})
+
+		time.Sleep(10 * time.Millisecond)
+
+		triggeredAt := time.Now()
+		graceful.RegisterProcess(trigger(graceful))
+
+		_ = graceful.Wait()
+
+		select {
+		case cancelledAt := <-cancelled:
+			assert.Less(t, cancelledAt.Sub(triggeredAt), 100*time.Millisecond, name)
+		default:
+			t.Errorf("%s: process is not cancelled", name)
+		}
+	}
+}
+
+func TestGraceful_Shutdowns(t *testing.T) {
+	graceful := New()
+	process := func(ctx context.Context) error {
+		return nil
+	}
+
+	assert.Empty(t, graceful.Shutdowns())
+
+	registeredAt := time.Now()
+	first := graceful.RegisterShutdownProcessWithTag(process, "first")
+	second := graceful.RegisterShutdownProcessWithPhase(process, "second", "storage")
+	shutdowns := graceful.Shutdowns()
+
+	assert.Len(t, shutdowns, 2)
+	assert.Equal(t, ShutdownInfo{ID: first, Tag: "first", RegisteredAt: shutdowns[0].RegisteredAt}, shutdowns[0])
+	assert.Equal(t, ShutdownInfo{ID: second, Tag: "second", Phase: "storage", RegisteredAt: shutdowns[1].RegisteredAt}, shutdowns[1])
+	assert.False(t, shutdowns[0].RegisteredAt.Before(registeredAt))
+	assert.False(t, shutdowns[1].RegisteredAt.Before(shutdowns[0].RegisteredAt))
+}
+
+// lockedBuffer buffer that is safe to write concurrently.
+type lockedBuffer struct {
+	buffer bytes.Buffer
+	mutex  sync.Mutex
+}
+
+func (b *lockedBuffer) Write(p []byte) (int, error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.Write(p)
+}
+
+func (b *lockedBuffer) String() string {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	return b.buffer.String()
+}
+
+// captureLogs redirect global logger to buffer until the test is done.
+func captureLogs(t *testing.T) *lockedBuffer {
+	logs := &lockedBuffer{}
+	logger := log.Logger
+	log.Logger = zerolog.New(logs)
+
+	t.Cleanup(func() {
+		log.Logger = logger
+	})
+
+	return logs
+}
+
+// callRecorder record name of the called shutdown process in call order, it's safe for concurrent use.
+type callRecorder struct {
+	calls []string
+	mutex sync.Mutex
+}
+
+// record record call by name.
+func (r *callRecorder) record(name string) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls = append(r.calls, name)
+}
+
+// hook get shutdown process that record call by name and return nil.
+func (r *callRecorder) hook(name string) func(ctx context.Context) error {
+	return r.hookErr(name, nil)
+}
+
+// hookErr get shutdown process that record call by name and return err.
+func (r *callRecorder) hookErr(name string, err error) func(ctx context.Context) error {
+	return func(ctx context.Context) error {
+		r.record(name)
+
+		return err
+	}
+}
+
+// list get copy of recorded calls.
+func (r *callRecorder) list() []string {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]string(nil), r.calls...)
+}
+
+// reset clear recorded calls.
+func (r *callRecorder) reset() {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.calls = nil
+}
+
 func sendSignal(sig os.Signal) {
 	p, err := os.FindProcess(os.Getpid())
 	if err != nil {
@@ -116,3 +1600,413 @@ func sendSignal(sig os.Signal) {
 	_ = p.Signal(sig)
 	time.Sleep(10 * time.Millisecond) // give signal some time to propagate
 }
+
+func TestGraceful_ShutdownJoinedErrors(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+
+	var (
+		errDatabase = errors.New("database closed")
+		errCache    = errors.New("cache closed")
+	)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errDatabase
+	}, "database")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errCache
+	}, "cache")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "queue")
+
+	err := graceful.Stop(context.Background())
+
+	assert.ErrorIs(t, err, errDatabase)
+	assert.ErrorIs(t, err, errCache)
+	assert.ErrorContains(t, err, "database: database closed")
+	assert.ErrorContains(t, err, "cache: cache closed")
+}
+
+func TestGraceful_ShutdownErrorsNotReported(t *testing.T) {
+	graceful := New()
+
+	errDatabase := errors.New("database closed")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errDatabase
+	}, "database")
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.ErrorIs(t, graceful.LastShutdownReport().Hooks[0].Err, errDatabase)
+	assert.ErrorIs(t, graceful.LastShutdownError(), errDatabase)
+}
+
+func TestGraceful_LastShutdownError(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+	graceful.SetCancelOnError(false)
+
+	assert.Nil(t, graceful.LastShutdownError())
+
+	var (
+		errDatabase = errors.New("database closed")
+		errCache    = errors.New("cache closed")
+	)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		time.Sleep(20 * time.Millisecond)
+
+		return errCache
+	}, "cache")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errDatabase
+	}, "database")
+
+	err := graceful.Stop(context.Background())
+	assert.ErrorIs(t, err, errCache)
+
+	first := graceful.LastShutdownError()
+	assert.ErrorIs(t, first, errDatabase)
+	assert.NotErrorIs(t, first, errCache)
+	assert.EqualError(t, first, "database: database closed")
+
+	assert.Nil(t, firstError(nil))
+	assert.Equal(t, errCache, firstError(errCache))
+}
+
+func TestGraceful_ShutdownEntryPoint(t *testing.T) {
+	graceful := New()
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.Empty(t, graceful.ShutdownEntryPoint())
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	assert.Nil(t, graceful.Wait())
+	assert.Equal(t, EntryPointWait, graceful.ShutdownEntryPoint())
+	assert.Equal(t, EntryPointWait, graceful.LastShutdownReport().EntryPoint)
+
+	stopped := New()
+	stopped.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.Nil(t, stopped.Stop(context.Background()))
+	assert.Equal(t, EntryPointStop, stopped.LastShutdownReport().EntryPoint)
+
+	drained := New()
+	drained.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.Nil(t, drained.DrainNow(context.Background()))
+	assert.Equal(t, EntryPointDrainNow, drained.LastShutdownReport().EntryPoint)
+}
+
+func TestGraceful_ParentContextCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	graceful := NewWithContext(ctx)
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	assert.Nil(t, graceful.Wait())
+
+	ctx, cancel = context.WithCancel(context.Background())
+	errClose := errors.New("close")
+
+	graceful = NewWithContext(ctx)
+	graceful.SetReportAllErrors(true)
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return ctx.Err()
+	})
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return errClose
+	})
+
+	cancel()
+
+	assert.ErrorIs(t, graceful.Wait(), errClose)
+}
+
+func TestGraceful_SetShutdownBatchRetry(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+	graceful.SetShutdownBatchRetry(2)
+
+	var (
+		flaky, broken, healthy int32
+		errBroken              = errors.New("broken")
+	)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		if atomic.AddInt32(&flaky, 1) == 1 {
+			return errors.New("connection reset")
+		}
+
+		return nil
+	}, "flaky")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		atomic.AddInt32(&broken, 1)
+
+		return errBroken
+	}, "broken")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		atomic.AddInt32(&healthy, 1)
+
+		return nil
+	}, "healthy")
+
+	err := graceful.Stop(context.Background())
+
+	assert.EqualError(t, err, "broken: broken")
+	assert.Equal(t, int32(2), atomic.LoadInt32(&flaky))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&broken))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&healthy))
+
+	report := graceful.LastShutdownReport()
+	assert.Equal(t, 2, report.BatchRetries)
+	assert.Len(t, report.Hooks, 6)
+
+	retries := make(map[string]int)
+	for _, hook := range report.Hooks {
+		if hook.Retry > retries[hook.Tag] {
+			retries[hook.Tag] = hook.Retry
+		}
+	}
+
+	assert.Equal(t, map[string]int{"flaky": 1, "broken": 2}, retries)
+}
+
+func TestGraceful_Unregister(t *testing.T) {
+	graceful := New()
+
+	var called []string
+
+	first := graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		called = append(called, "first")
+		return nil
+	}, "first")
+
+	second := graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		called = append(called, "second")
+		return nil
+	}, "second")
+
+	third := graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		called = append(called, "third")
+		return nil
+	}, "third")
+
+	ids := graceful.ShutdownIDs()
+	assert.Equal(t, []string{first, second, third}, ids)
+
+	ids[0] = "changed"
+	assert.Equal(t, first, graceful.ShutdownIDs()[0])
+
+	assert.True(t, graceful.Unregister(second))
+	assert.False(t, graceful.Unregister(second))
+	assert.False(t, graceful.Unregister("unknown"))
+	assert.Equal(t, []string{first, third}, graceful.ShutdownIDs())
+
+	graceful.SetMaxShutdownProcess(1)
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, []string{"first", "third"}, called)
+	assert.False(t, graceful.Unregister(first))
+}
+
+func TestGraceful_RegisterShutdownProcessDuplicateTag(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+
+	var called int32
+
+	for i := 0; i < 3; i++ {
+		assert.NotEmpty(t, graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+			atomic.AddInt32(&called, 1)
+
+			return nil
+		}, "cache"))
+	}
+
+	var tags []string
+	for _, info := range graceful.Shutdowns() {
+		tags = append(tags, info.Tag)
+	}
+
+	assert.Equal(t, []string{"cache", "cache#2", "cache#3"}, tags)
+	assert.Equal(t, 2, strings.Count(logs.String(), ErrDuplicateTag.Error()))
+	assert.Contains(t, logs.String(), `"graceful-registered-tag":"cache#3"`)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&called))
+}
+
+func TestGraceful_SetWarnDuplicateFuncs(t *testing.T) {
+	logs := captureLogs(t)
+
+	closeDatabase := func(ctx context.Context) error {
+		return nil
+	}
+
+	graceful := New()
+	graceful.RegisterShutdownProcessWithTag(closeDatabase, "database")
+	graceful.RegisterShutdownProcessWithTag(closeDatabase, "database-replica")
+
+	assert.Empty(t, logs.String())
+
+	graceful = New()
+	graceful.SetWarnDuplicateFuncs(true)
+	graceful.RegisterShutdownProcessWithTag(closeDatabase, "database")
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "cache")
+	graceful.RegisterShutdownAfterProcess(closeDatabase, "writer", "database-replica")
+
+	assert.Equal(t,
+		`{"level":"warn","graceful-shutdown-tag":"database-replica","duplicate-of":"database","message":"shutdown process function is already registered"}`,
+		strings.TrimSpace(logs.String()))
+	assert.Len(t, graceful.Shutdowns(), 3)
+}
+
+// duplicateCloser resource with close method, used to register method values of different receivers.
+type duplicateCloser struct {
+	name string
+}
+
+// Close close the resource.
+func (c *duplicateCloser) Close(ctx context.Context) error {
+	return nil
+}
+
+func TestGraceful_SetWarnDuplicateFuncsDifferentFuncs(t *testing.T) {
+	logs := captureLogs(t)
+
+	primary, replica := &duplicateCloser{name: "primary"}, &duplicateCloser{name: "replica"}
+
+	var calls callRecorder
+
+	graceful := New()
+	graceful.SetWarnDuplicateFuncs(true)
+	graceful.RegisterShutdownProcessWithTag(primary.Close, "database")
+	graceful.RegisterShutdownProcessWithTag(replica.Close, "database-replica")
+	graceful.RegisterShutdownProcessWithTag(calls.hook("cache"), "cache")
+	graceful.RegisterShutdownProcessWithTag(calls.hook("queue"), "queue")
+
+	// shutdown process registered by helpers is never checked.
+	graceful.NewGate()
+	graceful.NewGate()
+	graceful.RegisterTicker(time.Hour, func(ctx context.Context) error {
+		return nil
+	}, "ticker")
+	graceful.RegisterTicker(time.Hour, func(ctx context.Context) error {
+		return nil
+	}, "ticker-replica")
+	graceful.RegisterWorkerPool(1, func(ctx context.Context, job interface{}) error {
+		return nil
+	}, "worker")
+	graceful.RegisterWorkerPool(1, func(ctx context.Context, job interface{}) error {
+		return nil
+	}, "worker-replica")
+
+	assert.Empty(t, logs.String())
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.ElementsMatch(t, []string{"cache", "queue"}, calls.list())
+}
+
+func TestGraceful_Uptime(t *testing.T) {
+	graceful := New()
+
+	time.Sleep(50 * time.Millisecond)
+
+	assert.GreaterOrEqual(t, graceful.Uptime(), 50*time.Millisecond)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return nil
+	})
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	report := graceful.LastShutdownReport()
+	assert.GreaterOrEqual(t, report.Uptime, 50*time.Millisecond)
+	assert.LessOrEqual(t, report.Uptime, graceful.Uptime())
+}
+
+func TestGraceful_GroupContext(t *testing.T) {
+	graceful := New()
+	graceful.SetRunShutdownOnProcessError(false)
+
+	errProcess := errors.New("process")
+
+	graceful.RegisterProcess(func() error {
+		return errProcess
+	})
+
+	assert.ErrorIs(t, graceful.Wait(), errProcess)
+	assert.ErrorIs(t, graceful.GroupContext().Err(), context.Canceled)
+
+	derived, cancel := context.WithCancel(New().GroupContext())
+	defer cancel()
+
+	assert.Nil(t, derived.Err())
+}
+
+func TestGraceful_NotifyDone(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+
+	var (
+		first     = make(chan error, 1)
+		second    = make(chan error, 1)
+		full      = make(chan error, 1)
+		errFailed = errors.New("failed")
+	)
+
+	full <- nil
+
+	graceful.NotifyDone(first)
+	graceful.NotifyDone(second)
+	graceful.NotifyDone(full)
+	graceful.NotifyDone(nil)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return errFailed
+	})
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), errFailed)
+	assert.ErrorIs(t, <-first, errFailed)
+	assert.ErrorIs(t, <-second, errFailed)
+	assert.Nil(t, <-full)
+
+	late := make(chan error, 1)
+	graceful.NotifyDone(late)
+	assert.ErrorIs(t, <-late, errFailed)
+}
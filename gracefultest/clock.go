@@ -0,0 +1,187 @@
+package gracefultest
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// FakeClock clock that only moves when it's advanced, so timeout can be tested without waiting.
+type FakeClock struct {
+	now    time.Time
+	timers []*fakeTimer
+	mutex  sync.Mutex
+}
+
+// fakeTimer timer that is fired when fake clock is advanced past its time.
+type fakeTimer struct {
+	at   time.Time
+	fire func(now time.Time)
+}
+
+// NewFakeClock init fake clock using now as the current time.
+func NewFakeClock(now time.Time) *FakeClock {
+	return &FakeClock{
+		now: now,
+	}
+}
+
+// Now get current time of fake clock.
+func (c *FakeClock) Now() time.Time {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.now
+}
+
+// After get channel that receive the current time once fake clock is advanced by duration.
+func (c *FakeClock) After(duration time.Duration) <-chan time.Time {
+	ch := make(chan time.Time, 1)
+
+	c.schedule(duration, func(now time.Time) {
+		ch <- now
+	})
+
+	return ch
+}
+
+// WithTimeout get context that is done with context.DeadlineExceeded once fake clock is advanced by timeout,
+// or when parent is done or cancel is called.
+func (c *FakeClock) WithTimeout(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	ctx := &timeoutCtx{
+		Context:  parent,
+		deadline: c.Now().Add(timeout),
+		done:     make(chan struct{}),
+	}
+
+	timer := c.schedule(timeout, func(time.Time) {
+		ctx.cancel(context.DeadlineExceeded)
+	})
+
+	// the timer is removed once the context is done before it's fired, so it isn't counted as pending.
+	go func() {
+		select {
+		case <-parent.Done():
+			ctx.cancel(parent.Err())
+			c.stop(timer)
+		case <-ctx.done:
+		}
+	}()
+
+	return ctx, func() {
+		ctx.cancel(context.Canceled)
+		c.stop(timer)
+	}
+}
+
+// Advance move fake clock forward by duration and fire all timers that are due.
+func (c *FakeClock) Advance(duration time.Duration) {
+	c.mutex.Lock()
+	c.now = c.now.Add(duration)
+
+	var (
+		now     = c.now
+		due     []*fakeTimer
+		pending = c.timers[:0]
+	)
+
+	for _, timer := range c.timers {
+		if timer.at.After(now) {
+			pending = append(pending, timer)
+		} else {
+			due = append(due, timer)
+		}
+	}
+
+	c.timers = pending
+	c.mutex.Unlock()
+
+	for _, timer := range due {
+		timer.fire(now)
+	}
+}
+
+// Pending get number of timers that are waiting to be fired, e.g. to wait until shutdown process start its timeout.
+func (c *FakeClock) Pending() int {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return len(c.timers)
+}
+
+// schedule add timer that is fired after duration, timer that is already due is fired right away and nil is returned.
+func (c *FakeClock) schedule(duration time.Duration, fire func(now time.Time)) *fakeTimer {
+	c.mutex.Lock()
+
+	if duration <= 0 {
+		now := c.now
+		c.mutex.Unlock()
+
+		fire(now)
+
+		return nil
+	}
+
+	timer := &fakeTimer{
+		at:   c.now.Add(duration),
+		fire: fire,
+	}
+	c.timers = append(c.timers, timer)
+	c.mutex.Unlock()
+
+	return timer
+}
+
+// stop remove timer that is not fired yet, it's no-op when the timer is already fired or nil.
+func (c *FakeClock) stop(timer *fakeTimer) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	for i, t := range c.timers {
+		if t == timer {
+			c.timers = append(c.timers[:i:i], c.timers[i+1:]...)
+
+			return
+		}
+	}
+}
+
+// timeoutCtx context that is done on deadline of fake clock.
+type timeoutCtx struct {
+	context.Context
+	deadline time.Time
+	done     chan struct{}
+	err      error
+	mutex    sync.Mutex
+}
+
+// Deadline get deadline of fake clock.
+func (c *timeoutCtx) Deadline() (time.Time, bool) {
+	return c.deadline, true
+}
+
+// Done get channel that is closed when the context is done.
+func (c *timeoutCtx) Done() <-chan struct{} {
+	return c.done
+}
+
+// Err get the cancellation error.
+func (c *timeoutCtx) Err() error {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	return c.err
+}
+
+// cancel record err and close done channel when context is not done yet.
+func (c *timeoutCtx) cancel(err error) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+
+	if c.err != nil {
+		return
+	}
+
+	c.err = err
+	close(c.done)
+}
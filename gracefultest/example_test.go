@@ -0,0 +1,31 @@
+package gracefultest_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"syscall"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/erry-az/go-graceful/gracefultest"
+)
+
+func ExampleRecorder() {
+	g := graceful.New()
+	recorder := gracefultest.NewRecorder(g)
+
+	g.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errors.New("connection reset")
+	}, "database")
+
+	// the signal is handled before Wait is started, like an operator stops the app right away.
+	_ = gracefultest.SendSignal(g, syscall.SIGTERM)
+	_ = g.Wait()
+	recorder.Wait()
+
+	fmt.Println(recorder.Types())
+	fmt.Println(recorder.HookErrors()["database"])
+	// Output:
+	// [signal-received started shutdown-began hook-started hook-finished shutdown-complete]
+	// database: connection reset
+}
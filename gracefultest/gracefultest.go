@@ -0,0 +1,25 @@
+// Package gracefultest provide helpers to test shutdown wiring of graceful deterministically,
+// like fake clock, os signal injector and recorder of lifecycle events.
+package gracefultest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/erry-az/go-graceful"
+)
+
+// ErrSignalNotHandled os signal is not handled by graceful, e.g. graceful from NewFromContext.
+var ErrSignalNotHandled = errors.New("gracefultest: signal is not handled")
+
+// SendSignal deliver os signal to g like a real os signal without signaling the current process,
+// so it works on every os and doesn't affect other graceful in the same test binary.
+// it returns once the signal is handled.
+func SendSignal(g *graceful.Graceful, sig os.Signal) error {
+	if !g.InjectSignal(sig) {
+		return fmt.Errorf("%w: %s", ErrSignalNotHandled, sig)
+	}
+
+	return nil
+}
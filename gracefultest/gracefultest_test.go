@@ -0,0 +1,175 @@
+package gracefultest
+
+import (
+	"context"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFakeClock(t *testing.T) {
+	startedAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewFakeClock(startedAt)
+
+	after := clock.After(time.Second)
+	ctx, cancel := clock.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	deadline, ok := ctx.Deadline()
+	assert.True(t, ok)
+	assert.Equal(t, startedAt.Add(2*time.Second), deadline)
+	assert.Equal(t, 2, clock.Pending())
+
+	clock.Advance(time.Second)
+
+	assert.Equal(t, startedAt.Add(time.Second), <-after)
+	assert.Nil(t, ctx.Err())
+	assert.Equal(t, 1, clock.Pending())
+
+	clock.Advance(time.Second)
+
+	<-ctx.Done()
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+	assert.Equal(t, 0, clock.Pending())
+	assert.Equal(t, startedAt.Add(2*time.Second), clock.Now())
+}
+
+func TestFakeClock_WithTimeoutCancel(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+	parent, parentCancel := context.WithCancel(context.Background())
+
+	ctx, cancel := clock.WithTimeout(context.Background(), time.Second)
+	cancel()
+	clock.Advance(time.Second)
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	ctx, cancel = clock.WithTimeout(parent, time.Second)
+	defer cancel()
+
+	parentCancel()
+	<-ctx.Done()
+	clock.Advance(time.Second)
+
+	assert.ErrorIs(t, ctx.Err(), context.Canceled)
+
+	ctx, cancel = clock.WithTimeout(context.Background(), 0)
+	defer cancel()
+
+	assert.ErrorIs(t, ctx.Err(), context.DeadlineExceeded)
+}
+
+func TestFakeClock_WithTimeoutPending(t *testing.T) {
+	clock := NewFakeClock(time.Now())
+
+	for i := 0; i < 3; i++ {
+		_, cancel := clock.WithTimeout(context.Background(), time.Second)
+		cancel()
+	}
+
+	assert.Equal(t, 0, clock.Pending())
+
+	parent, parentCancel := context.WithCancel(context.Background())
+
+	_, cancel := clock.WithTimeout(parent, time.Second)
+	defer cancel()
+
+	assert.Equal(t, 1, clock.Pending())
+
+	// the timer is removed asynchronously once the parent is done.
+	parentCancel()
+
+	assert.Eventually(t, func() bool {
+		return clock.Pending() == 0
+	}, time.Second, time.Millisecond)
+}
+
+func TestRecorder(t *testing.T) {
+	g := graceful.New()
+	recorder := NewRecorder(g)
+
+	g.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "http-server")
+
+	assert.Nil(t, SendSignal(g, syscall.SIGTERM))
+	assert.ErrorIs(t, SendSignal(g, os.Kill), ErrSignalNotHandled)
+
+	assert.Nil(t, g.Wait())
+	recorder.Wait()
+
+	assert.Equal(t, []graceful.EventType{
+		graceful.EventSignalReceived,
+		graceful.EventStarted,
+		graceful.EventShutdownBegan,
+		graceful.EventHookStarted,
+		graceful.EventHookFinished,
+		graceful.EventShutdownComplete,
+	}, recorder.Types())
+	assert.Len(t, recorder.Events(), 6)
+	assert.Equal(t, map[string]error{"http-server": nil}, recorder.HookErrors())
+}
+
+func TestFakeClock_SetClock(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	g := graceful.New()
+	g.SetClock(clock)
+	g.SetMaxShutdownTime(time.Hour)
+	g.SetSoftShutdownTimeout(30 * time.Minute)
+
+	release := make(chan struct{})
+	defer close(release)
+
+	g.RegisterShutdownProcess(func(ctx context.Context) error {
+		<-ctx.Done()
+		<-release
+
+		return nil
+	})
+
+	go func() {
+		// wait for hard and soft shutdown timeout to be started.
+		for clock.Pending() < 2 {
+			time.Sleep(time.Millisecond)
+		}
+
+		clock.Advance(30 * time.Minute)
+		clock.Advance(30 * time.Minute)
+	}()
+
+	startedAt := time.Now()
+	err := g.Stop(context.Background())
+
+	assert.ErrorIs(t, err, graceful.ErrShutdownTimeout)
+	assert.Less(t, time.Since(startedAt), time.Second)
+	assert.Equal(t, time.Hour, g.LastShutdownReport().Total)
+}
+
+func TestFakeClock_SetClockBlockedTime(t *testing.T) {
+	clock := NewFakeClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	g := graceful.New()
+	g.SetClock(clock)
+	g.SetMaxShutdownTime(time.Hour)
+	g.SetMaxShutdownProcess(1)
+
+	for i := 0; i < 2; i++ {
+		g.RegisterShutdownProcess(func(ctx context.Context) error {
+			clock.Advance(time.Minute)
+
+			return nil
+		})
+	}
+
+	assert.Nil(t, g.Stop(context.Background()))
+
+	// the second shutdown process waits for the first one using the fake clock too.
+	report := g.LastShutdownReport()
+	assert.Equal(t, time.Minute, report.BlockedTime)
+	assert.Equal(t, 2*time.Minute, report.Total)
+}
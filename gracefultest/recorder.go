@@ -0,0 +1,78 @@
+package gracefultest
+
+import (
+	"sync"
+
+	"github.com/erry-az/go-graceful"
+)
+
+// Recorder capture lifecycle events of graceful and the result of each shutdown process.
+type Recorder struct {
+	events []graceful.Event
+	done   chan struct{}
+	mutex  sync.Mutex
+}
+
+// NewRecorder init recorder that consume the events of g until the events channel is closed,
+// it must be called before Wait and it's the only consumer of g.Events.
+func NewRecorder(g *graceful.Graceful) *Recorder {
+	r := &Recorder{
+		done: make(chan struct{}),
+	}
+
+	events := g.Events()
+
+	go func() {
+		defer close(r.done)
+
+		for event := range events {
+			r.mutex.Lock()
+			r.events = append(r.events, event)
+			r.mutex.Unlock()
+		}
+	}()
+
+	return r
+}
+
+// Wait wait until the events channel is closed, which is after EventShutdownComplete.
+func (r *Recorder) Wait() {
+	<-r.done
+}
+
+// Events get copy of recorded events.
+func (r *Recorder) Events() []graceful.Event {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]graceful.Event(nil), r.events...)
+}
+
+// Types get type of recorded events in order.
+func (r *Recorder) Types() []graceful.EventType {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	types := make([]graceful.EventType, 0, len(r.events))
+	for _, event := range r.events {
+		types = append(types, event.Type)
+	}
+
+	return types
+}
+
+// HookErrors get error of each finished shutdown process by its tag, nil error means success.
+func (r *Recorder) HookErrors() map[string]error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	hooks := make(map[string]error)
+
+	for _, event := range r.events {
+		if event.Type == graceful.EventHookFinished {
+			hooks[event.Tag] = event.Err
+		}
+	}
+
+	return hooks
+}
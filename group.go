@@ -0,0 +1,175 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"golang.org/x/sync/errgroup"
+)
+
+// ShutdownGroup group of shutdown process that run concurrently as single shutdown process using the group name,
+// so failure, abort or panic of shutdown process in the group is contained to the group result.
+type ShutdownGroup struct {
+	graceful *Graceful
+	name     string
+	hooks    []shutdown
+	mutex    sync.Mutex
+}
+
+// RegisterShutdownGroup register shutdown group using name as the shutdown process tag.
+// the group is run using the shutdown ordering like other shutdown process, and its shutdown process are run concurrently.
+// error of shutdown process in the group is returned as the group result, but it never cancels shutdown process
+// outside the group even when cancel on error is enabled, and panic is recovered as error wrapped with ErrShutdownPanic.
+func (g *Graceful) RegisterShutdownGroup(name string) *ShutdownGroup {
+	group := &ShutdownGroup{
+		graceful: g,
+		name:     name,
+	}
+
+	shutdownProcess := newShutdown(name, func(ctx context.Context) error {
+		return group.run(ctx, nil)
+	})
+	shutdownProcess.group = group
+
+	g.registerShutdown("RegisterShutdownGroup", shutdownProcess)
+
+	return group
+}
+
+// RegisterShutdownProcess register shutdown process to the group, it returns the shutdown process id.
+func (sg *ShutdownGroup) RegisterShutdownProcess(process func(ctx context.Context) error) string {
+	return sg.RegisterShutdownProcessWithTag(process, "")
+}
+
+// RegisterShutdownProcessWithTag register shutdown process to the group using tag, it returns the shutdown process id.
+func (sg *ShutdownGroup) RegisterShutdownProcessWithTag(process func(ctx context.Context) error, tag string) string {
+	if process == nil {
+		checkNilProcess("ShutdownGroup.RegisterShutdownProcessWithTag")
+
+		return ""
+	}
+
+	sg.graceful.mutex.Lock()
+	id := sg.graceful.idGenerator()
+	sg.graceful.mutex.Unlock()
+
+	hook := newShutdown(tag, process)
+	hook.id = id
+
+	if hook.tag == "" {
+		hook.tag = id
+	}
+
+	sg.mutex.Lock()
+	defer sg.mutex.Unlock()
+
+	sg.hooks = append(sg.hooks, hook)
+
+	return id
+}
+
+// run run all shutdown process in the group concurrently and record their report to run,
+// error that should cancel other shutdown process only cancels the other shutdown process in the group.
+func (sg *ShutdownGroup) run(ctx context.Context, run *shutdownRun) error {
+	sg.mutex.Lock()
+	hooks := append([]shutdown(nil), sg.hooks...)
+	sg.mutex.Unlock()
+
+	var (
+		g               = sg.graceful
+		errs            = make([]error, len(hooks))
+		group, groupCtx = errgroup.WithContext(ctx)
+	)
+
+	for i, hook := range hooks {
+		index, hookCopy := i, hook
+
+		group.Go(func() error {
+			startedAt := g.clock.Now()
+			err := tagError(hookCopy.tag, hookTimeoutError(groupCtx, callRecovered(groupCtx, hookCopy.process)))
+
+			if run != nil {
+				run.addGroupHook(sg.name, newHookReport(hookCopy, g.clock.Now().Sub(startedAt), err))
+			}
+
+			// context cancelled by another failed shutdown process in the group is not the failure of this one.
+			var (
+				nonFatal  *nonFatalError
+				cancelled = errors.Is(err, context.Canceled) && groupCtx.Err() != nil && ctx.Err() == nil
+			)
+
+			if !errors.As(err, &nonFatal) && !cancelled {
+				errs[index] = err
+			}
+
+			if isCancellationError(err, g.cancelOnError) {
+				return err
+			}
+
+			return nil
+		})
+	}
+
+	_ = group.Wait()
+
+	return joinErrors(errs)
+}
+
+// callRecovered call shutdown process and recover its panic as error wrapped with ErrShutdownPanic.
+func callRecovered(ctx context.Context, process func(ctx context.Context) error) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%w: %v", ErrShutdownPanic, r)
+		}
+	}()
+
+	return process(ctx)
+}
+
+// joinErrors join non nil errors using errors.Join, single error is returned as is.
+func joinErrors(errs []error) error {
+	var list []error
+
+	for _, err := range errs {
+		if err != nil {
+			list = append(list, err)
+		}
+	}
+
+	if len(list) == 1 {
+		return list[0]
+	}
+
+	return errors.Join(list...)
+}
+
+// addGroupHook record report of shutdown process in the group.
+func (r *shutdownRun) addGroupHook(group string, hook HookReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.groups == nil {
+		r.groups = make(map[string][]HookReport)
+	}
+
+	r.groups[group] = append(r.groups[group], hook)
+}
+
+// groupsReport get copy of recorded shutdown group reports, nil when no group is run.
+func (r *shutdownRun) groupsReport() map[string][]HookReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.groups) == 0 {
+		return nil
+	}
+
+	groups := make(map[string][]HookReport, len(r.groups))
+	for group, hooks := range r.groups {
+		groups[group] = append([]HookReport(nil), hooks...)
+	}
+
+	return groups
+}
@@ -0,0 +1,59 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterShutdownGroup(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+	graceful.SetCancelOnError(true)
+
+	var (
+		errQueue      = errors.New("queue closed")
+		storageClosed bool
+		siblingErr    error
+	)
+
+	messaging := graceful.RegisterShutdownGroup("messaging")
+	messaging.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		panic("double close")
+	}, "producer")
+	messaging.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errQueue
+	}, "queue")
+	messaging.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		<-ctx.Done()
+		siblingErr = ctx.Err()
+
+		return ctx.Err()
+	}, "consumer")
+
+	storage := graceful.RegisterShutdownGroup("storage")
+	storage.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		storageClosed = ctx.Err() == nil
+
+		return nil
+	}, "database")
+
+	err := graceful.Stop(context.Background())
+
+	assert.ErrorIs(t, err, ErrShutdownPanic)
+	assert.ErrorIs(t, err, errQueue)
+	assert.NotErrorIs(t, err, context.Canceled)
+	assert.ErrorIs(t, siblingErr, context.Canceled)
+	assert.True(t, storageClosed)
+
+	report := graceful.LastShutdownReport()
+	assert.Len(t, report.Hooks, 2)
+	assert.Len(t, report.Groups["messaging"], 3)
+	assert.Len(t, report.Groups["storage"], 1)
+	assert.Nil(t, report.Groups["storage"][0].Err)
+	assert.Equal(t, "database", report.Groups["storage"][0].Tag)
+}
@@ -0,0 +1,44 @@
+// Package grpc integrate graceful with grpc client connection.
+// it's a separate module, so the grpc dependency is not required by the graceful module.
+package grpc
+
+import (
+	"context"
+
+	"github.com/erry-az/go-graceful"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+)
+
+// RegisterClientConn register shutdown process using tag that close conn, the in-flight rpc of conn
+// are failed right away once it's closed.
+func RegisterClientConn(g *graceful.Graceful, conn *grpc.ClientConn, tag string) string {
+	return g.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return conn.Close()
+	}, tag)
+}
+
+// RegisterSettledClientConn register shutdown process using tag that wait until conn leaves connecting state
+// before closing it, so the rpc that is queued on the connection attempt is sent or failed fast instead of
+// being cut by close. conn is still closed when the shutdown context is done first.
+func RegisterSettledClientConn(g *graceful.Graceful, conn *grpc.ClientConn, tag string) string {
+	return g.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		waitSettled(ctx, conn)
+
+		return conn.Close()
+	}, tag)
+}
+
+// waitSettled wait until conn state is not connecting or ctx is done.
+func waitSettled(ctx context.Context, conn *grpc.ClientConn) {
+	for {
+		state := conn.GetState()
+		if state != connectivity.Connecting {
+			return
+		}
+
+		if !conn.WaitForStateChange(ctx, state) {
+			return
+		}
+	}
+}
@@ -0,0 +1,114 @@
+package grpc
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/connectivity"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+func listen(t *testing.T) net.Listener {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return listener
+}
+
+func dial(t *testing.T, listener net.Listener) *grpc.ClientConn {
+	conn, err := grpc.Dial(listener.Addr().String(), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return conn
+}
+
+func TestRegisterClientConn(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+
+	listener := listen(t)
+	server := grpc.NewServer()
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn := dial(t, listener)
+	conn.Connect()
+
+	assert.NotEmpty(t, RegisterClientConn(g, conn, "grpc-client"))
+	assert.Nil(t, g.Stop(context.Background()))
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+}
+
+func TestRegisterSettledClientConn(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+
+	listener := listen(t)
+	server := grpc.NewServer()
+
+	go func() {
+		_ = server.Serve(listener)
+	}()
+
+	t.Cleanup(server.Stop)
+
+	conn := dial(t, listener)
+	conn.Connect()
+
+	assert.NotEmpty(t, RegisterSettledClientConn(g, conn, "grpc-client"))
+	assert.Nil(t, g.Stop(context.Background()))
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+}
+
+func TestRegisterSettledClientConnTimeout(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+	g.SetMaxShutdownTime(100 * time.Millisecond)
+
+	// the listener accepts the connection without speaking http2, so conn is stuck on connecting.
+	listener := listen(t)
+	t.Cleanup(func() {
+		_ = listener.Close()
+	})
+
+	go func() {
+		for {
+			if _, err := listener.Accept(); err != nil {
+				return
+			}
+		}
+	}()
+
+	conn := dial(t, listener)
+	conn.Connect()
+
+	RegisterSettledClientConn(g, conn, "grpc-client")
+
+	startedAt := time.Now()
+
+	assert.ErrorIs(t, g.Stop(context.Background()), graceful.ErrShutdownTimeout)
+	assert.Less(t, time.Since(startedAt), time.Second)
+	assert.Equal(t, connectivity.Shutdown, conn.GetState())
+}
+
+func TestRegisterClientConnAfterShutdown(t *testing.T) {
+	g := graceful.NewFromContext(context.Background())
+	assert.Nil(t, g.Stop(context.Background()))
+
+	conn := dial(t, listen(t))
+	t.Cleanup(func() {
+		_ = conn.Close()
+	})
+
+	assert.Empty(t, RegisterClientConn(g, conn, "grpc-client"))
+}
@@ -0,0 +1,75 @@
+package graceful
+
+import (
+	"context"
+	"sync"
+)
+
+// runningHook running shutdown process that can be cancelled using CancelHook.
+type runningHook struct {
+	cancel    context.CancelFunc
+	cancelled bool
+	mutex     sync.Mutex
+}
+
+// CancelHook cancel context of running shutdown process with the tag without affecting other shutdown process,
+// e.g. from admin tooling when the shutdown process is stuck. cooperative shutdown process is returned and
+// its error is recorded wrapped with ErrHookCancelled as non fatal error. it returns false when no shutdown process
+// with the tag is running.
+func (g *Graceful) CancelHook(tag string) bool {
+	g.mutex.Lock()
+	hook, ok := g.runningHooks[tag]
+	g.mutex.Unlock()
+
+	if !ok {
+		return false
+	}
+
+	hook.mutex.Lock()
+	hook.cancelled = true
+	hook.mutex.Unlock()
+
+	hook.cancel()
+
+	return true
+}
+
+// startHook track running shutdown process with the tag and get its own context from ctx.
+func (g *Graceful) startHook(ctx context.Context, tag string) (context.Context, *runningHook) {
+	hookCtx, cancel := context.WithCancel(withGraceful(ctx, g))
+	hook := &runningHook{cancel: cancel}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.runningHooks == nil {
+		g.runningHooks = make(map[string]*runningHook)
+	}
+
+	g.runningHooks[tag] = hook
+
+	return hookCtx, hook
+}
+
+// finishHook stop tracking running shutdown process with the tag.
+func (g *Graceful) finishHook(tag string, hook *runningHook) {
+	g.mutex.Lock()
+	if g.runningHooks[tag] == hook {
+		delete(g.runningHooks, tag)
+	}
+	g.mutex.Unlock()
+
+	hook.cancel()
+}
+
+// result get shutdown process error wrapped with ErrHookCancelled as non fatal error when it's cancelled using CancelHook.
+func (h *runningHook) result(err error) error {
+	h.mutex.Lock()
+	defer h.mutex.Unlock()
+
+	if err == nil || !h.cancelled {
+		return err
+	}
+
+	return NonFatal(wrapSentinel(ErrHookCancelled, err))
+}
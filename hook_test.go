@@ -0,0 +1,54 @@
+package graceful
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_CancelHook(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+
+	var (
+		started     = make(chan struct{})
+		otherCalled bool
+	)
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, "stuck")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		<-started
+		time.Sleep(100 * time.Millisecond)
+
+		otherCalled = ctx.Err() == nil
+
+		return nil
+	}, "healthy")
+
+	go func() {
+		<-started
+		assert.False(t, graceful.CancelHook("unknown"))
+		assert.True(t, graceful.CancelHook("stuck"))
+	}()
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.True(t, otherCalled)
+	assert.False(t, graceful.CancelHook("stuck"))
+
+	for _, hook := range graceful.LastShutdownReport().Hooks {
+		if hook.Tag == "stuck" {
+			assert.ErrorIs(t, hook.Err, ErrHookCancelled)
+			assert.ErrorIs(t, hook.Err, context.Canceled)
+		} else {
+			assert.Nil(t, hook.Err)
+		}
+	}
+}
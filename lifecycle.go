@@ -0,0 +1,79 @@
+package graceful
+
+// OnStart register callback that is called synchronously when Wait is started,
+// e.g. to report readiness after all processes are registered.
+func (g *Graceful) OnStart(callback func()) {
+	if callback == nil {
+		checkNilProcess("OnStart")
+
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.onStart = append(g.onStart, callback)
+}
+
+// OnShutdownStart register callback that is called synchronously when shutdown is started,
+// before any shutdown process is run.
+func (g *Graceful) OnShutdownStart(callback func()) {
+	if callback == nil {
+		checkNilProcess("OnShutdownStart")
+
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.onShutdownStart = append(g.onShutdownStart, callback)
+}
+
+// runCallbacks call all callbacks in registration order.
+func (g *Graceful) runCallbacks(callbacks *[]func()) {
+	g.mutex.Lock()
+	list := append([]func(){}, *callbacks...)
+	g.mutex.Unlock()
+
+	for _, callback := range list {
+		callback()
+	}
+}
+
+// NotifyDone register channel that the final result is sent on when Wait is returned or the lifecycle is done
+// by DrainNow, multiple channels are all notified in registration order. the send is non-blocking,
+// so the channel must be buffered or being received, otherwise the result is dropped. the channel is never closed,
+// and it's notified right away when the lifecycle is already done. nil channel is ignored,
+// or it panics with ErrNilProcess when strict nil is enabled.
+func (g *Graceful) NotifyDone(ch chan<- error) {
+	if ch == nil {
+		checkNilProcess("NotifyDone")
+
+		return
+	}
+
+	g.mutex.Lock()
+
+	if g.state == stateDone {
+		err := g.waitErr
+		g.mutex.Unlock()
+
+		notifyDone([]chan<- error{ch}, err)
+
+		return
+	}
+
+	g.doneNotifies = append(g.doneNotifies, ch)
+	g.mutex.Unlock()
+}
+
+// notifyDone send the final result on channels without blocking.
+func notifyDone(channels []chan<- error, err error) {
+	for _, ch := range channels {
+		select {
+		case ch <- err:
+		default:
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package graceful
+
+import (
+	"context"
+
+	"github.com/rs/zerolog/log"
+)
+
+// SetShutdownLimiter set shutdown limiter that bound shutdown process concurrency in place of max shutdown process
+// and phase concurrency, e.g. shared global semaphore across multiple subsystems. acquire is called before each
+// shutdown process with the shutdown context and the returned release is called after it's done. shutdown process
+// is skipped when acquire returns error, as SkipReasonContextCancelled when the shutdown context is done or
+// SkipReasonLimiter otherwise. nil acquire will reset it to use max shutdown process, which is the default value.
+func (g *Graceful) SetShutdownLimiter(acquire func(ctx context.Context) (release func(), err error)) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.shutdownLimiter = acquire
+}
+
+// acquireShutdown acquire shutdown limiter for shutdown process, ok is false when it's failed
+// and the shutdown process is recorded as skipped.
+func (g *Graceful) acquireShutdown(
+	ctx context.Context,
+	acquire func(ctx context.Context) (release func(), err error),
+	s shutdown,
+	run *shutdownRun,
+) (release func(), ok bool) {
+	release, err := acquire(ctx)
+	if err == nil {
+		if release == nil {
+			release = func() {}
+		}
+
+		return release, true
+	}
+
+	if ctx.Err() != nil {
+		run.recorder.skip([]shutdown{s}, SkipReasonContextCancelled)
+
+		return nil, false
+	}
+
+	log.Error().Fields(g.logFields).Str(shutdownTag, s.tag).Err(err).Msg(shutdownLimiterMessage)
+	run.recorder.skip([]shutdown{s}, SkipReasonLimiter)
+
+	return nil, false
+}
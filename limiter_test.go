@@ -0,0 +1,76 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetShutdownLimiter(t *testing.T) {
+	var (
+		semaphore = make(chan struct{}, 1)
+		acquired  int32
+		released  int32
+	)
+
+	graceful := New()
+	graceful.SetShutdownLimiter(func(ctx context.Context) (func(), error) {
+		select {
+		case semaphore <- struct{}{}:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+
+		atomic.AddInt32(&acquired, 1)
+
+		return func() {
+			atomic.AddInt32(&released, 1)
+			<-semaphore
+		}, nil
+	})
+
+	for i := 0; i < 3; i++ {
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			time.Sleep(10 * time.Millisecond)
+
+			return nil
+		})
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	report := graceful.LastShutdownReport()
+	assert.Equal(t, 1, report.PeakConcurrency)
+	assert.Len(t, report.Hooks, 3)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&acquired))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&released))
+	assert.True(t, graceful.Config().ShutdownLimiter)
+}
+
+func TestGraceful_SetShutdownLimiterError(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownLimiter(func(ctx context.Context) (func(), error) {
+		return nil, errors.New("budget exhausted")
+	})
+
+	var called bool
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		called = true
+
+		return nil
+	}, "database")
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.False(t, called)
+
+	report := graceful.LastShutdownReport()
+	if assert.Len(t, report.Skipped, 1) {
+		assert.Equal(t, "database", report.Skipped[0].Tag)
+		assert.Equal(t, SkipReasonLimiter, report.Skipped[0].Reason)
+	}
+}
@@ -0,0 +1,51 @@
+package graceful
+
+import (
+	"sync"
+
+	"github.com/rs/zerolog"
+	"github.com/rs/zerolog/log"
+)
+
+// errorLogBuffer buffer shutdown process error logs, so identical error message is logged once
+// with the count and the tags in first seen order.
+type errorLogBuffer struct {
+	messages []string
+	tags     map[string][]string
+	mutex    sync.Mutex
+}
+
+// newErrorLogBuffer init empty error log buffer.
+func newErrorLogBuffer() *errorLogBuffer {
+	return &errorLogBuffer{
+		tags: make(map[string][]string),
+	}
+}
+
+// add buffer error log of shutdown process tag.
+func (b *errorLogBuffer) add(tag string, err error) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	message := err.Error()
+	if _, ok := b.tags[message]; !ok {
+		b.messages = append(b.messages, message)
+	}
+
+	b.tags[message] = append(b.tags[message], tag)
+}
+
+// flush log one line for each buffered error message with the structured fields.
+func (b *errorLogBuffer) flush(fields map[string]interface{}) {
+	b.mutex.Lock()
+	defer b.mutex.Unlock()
+
+	for _, message := range b.messages {
+		tags := b.tags[message]
+
+		log.Error().Fields(fields).Strs(shutdownTagsTag, tags).Int(errorCountTag, len(tags)).Str(zerolog.ErrorFieldName, message).Send()
+	}
+
+	b.messages = nil
+	b.tags = make(map[string][]string)
+}
@@ -0,0 +1,99 @@
+package graceful
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetCoalesceErrorLogs(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetCoalesceErrorLogs(true)
+	graceful.SetMaxShutdownProcess(1)
+
+	for _, tag := range []string{"database", "cache", "queue"} {
+		graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+			return errors.New("connection refused")
+		}, tag)
+	}
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errors.New("disk full")
+	}, "file")
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+
+	assert.Equal(t, []string{
+		`{"level":"error","graceful-shutdown-tags":["database","cache","queue"],"error-count":3,"error":"connection refused"}`,
+		`{"level":"error","graceful-shutdown-tags":["file"],"error-count":1,"error":"disk full"}`,
+	}, lines)
+}
+
+func TestGraceful_ShutdownLogDuration(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		time.Sleep(50 * time.Millisecond)
+		return nil
+	}, "http-server")
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	var line struct {
+		Tag      string  `json:"graceful-shutdown-tag"`
+		Duration float64 `json:"duration"`
+		Message  string  `json:"message"`
+	}
+
+	assert.Nil(t, json.Unmarshal([]byte(strings.TrimSpace(logs.String())), &line))
+	assert.Equal(t, "http-server", line.Tag)
+	assert.Equal(t, shutdownSuccessMessage, line.Message)
+	assert.GreaterOrEqual(t, line.Duration, float64(50))
+}
+
+func TestGraceful_SetLogFields(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+	graceful.SetMaxShutdownProcess(1)
+	graceful.SetLogFields(map[string]interface{}{
+		"service": "billing",
+		"version": "1.2.0",
+	})
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "http-server")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return errors.New("connection refused")
+	}, "database")
+
+	assert.Error(t, graceful.Stop(context.Background()))
+
+	lines := strings.Split(strings.TrimSpace(logs.String()), "\n")
+	assert.Len(t, lines, 2)
+
+	for _, raw := range lines {
+		var line struct {
+			Service string `json:"service"`
+			Version string `json:"version"`
+		}
+
+		assert.Nil(t, json.Unmarshal([]byte(raw), &line))
+		assert.Equal(t, "billing", line.Service)
+		assert.Equal(t, "1.2.0", line.Version)
+	}
+}
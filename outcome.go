@@ -0,0 +1,52 @@
+package graceful
+
+import (
+	"errors"
+)
+
+// Outcome how the lifecycle is ended, it's derived from the same result returned by Wait.
+type Outcome string
+
+const (
+	// OutcomeClean Wait returns nil, or the cancel cause when SetReturnCancelCause is enabled.
+	OutcomeClean Outcome = "clean"
+	// OutcomeTimedOut Wait returns error that match ErrShutdownTimeout.
+	OutcomeTimedOut Outcome = "timed-out"
+	// OutcomeHookError Wait returns error of shutdown process.
+	OutcomeHookError Outcome = "hook-error"
+	// OutcomeProcessError Wait returns error of background process or preflight check.
+	OutcomeProcessError Outcome = "process-error"
+	// OutcomeForcedExit the process is exited by force exit, see SetForceExitWindow. it's only observable
+	// by the exit flush, since Wait never returns.
+	OutcomeForcedExit Outcome = "forced-exit"
+)
+
+// LastOutcome get outcome of the last lifecycle, it's set right before Wait returns or the lifecycle is done by
+// DrainNow, so dashboard can count clean and dirty exits without inspecting the error. it's empty when
+// the lifecycle is not done yet, and it's kept after Reset until the next lifecycle is done.
+func (g *Graceful) LastOutcome() Outcome {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.lastOutcome
+}
+
+// setOutcome set outcome of the last lifecycle.
+func (g *Graceful) setOutcome(outcome Outcome) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.lastOutcome = outcome
+}
+
+// shutdownOutcome get outcome of shutdown process result, timeout take precedence over shutdown process error.
+func shutdownOutcome(err error) Outcome {
+	switch {
+	case err == nil:
+		return OutcomeClean
+	case errors.Is(err, ErrShutdownTimeout):
+		return OutcomeTimedOut
+	default:
+		return OutcomeHookError
+	}
+}
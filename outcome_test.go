@@ -0,0 +1,125 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_LastOutcome(t *testing.T) {
+	tests := []struct {
+		name     string
+		setup    func(g *Graceful)
+		expected Outcome
+	}{
+		{
+			name:     "clean",
+			setup:    func(g *Graceful) {},
+			expected: OutcomeClean,
+		},
+		{
+			name: "timed out",
+			setup: func(g *Graceful) {
+				g.SetMaxShutdownTime(50 * time.Millisecond)
+				g.RegisterShutdownProcess(func(ctx context.Context) error {
+					<-ctx.Done()
+
+					return ctx.Err()
+				})
+			},
+			expected: OutcomeTimedOut,
+		},
+		{
+			name: "hook error",
+			setup: func(g *Graceful) {
+				g.RegisterShutdownProcess(func(ctx context.Context) error {
+					return errors.New("shutdown err")
+				})
+			},
+			expected: OutcomeHookError,
+		},
+		{
+			name: "process error",
+			setup: func(g *Graceful) {
+				g.RegisterProcess(func() error {
+					return errors.New("process err")
+				})
+			},
+			expected: OutcomeProcessError,
+		},
+		{
+			name: "preflight error",
+			setup: func(g *Graceful) {
+				g.RegisterPreflight(func(ctx context.Context) error {
+					return errors.New("preflight err")
+				})
+			},
+			expected: OutcomeProcessError,
+		},
+		{
+			name: "cancel cause",
+			setup: func(g *Graceful) {
+				g.SetReturnCancelCause(true)
+				g.RegisterProcessWithContext(func(ctx context.Context) error {
+					<-ctx.Done()
+
+					return ctx.Err()
+				})
+			},
+			expected: OutcomeClean,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			graceful := New()
+			graceful.SetReportAllErrors(true)
+			test.setup(graceful)
+
+			assert.Empty(t, graceful.LastOutcome())
+
+			go graceful.trigger()
+
+			_ = graceful.Wait()
+
+			assert.Equal(t, test.expected, graceful.LastOutcome())
+		})
+	}
+}
+
+func TestGraceful_LastOutcomeDrainNow(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		return errors.New("shutdown err")
+	})
+
+	assert.NotNil(t, graceful.DrainNow(context.Background()))
+	assert.Equal(t, OutcomeHookError, graceful.LastOutcome())
+}
+
+func TestGraceful_LastOutcomeForcedExit(t *testing.T) {
+	osExit = func(code int) {}
+
+	t.Cleanup(func() {
+		osExit = os.Exit
+	})
+
+	// os signal is not handled, so the force exit window doesn't outlive the test.
+	graceful := NewFromContext(context.Background())
+	graceful.SetForceExitWindow(time.Second)
+
+	var outcome Outcome
+
+	graceful.SetExitFlush(func() {
+		outcome = graceful.LastOutcome()
+	})
+
+	graceful.forceExit(os.Interrupt, 0)
+
+	assert.Equal(t, OutcomeForcedExit, outcome)
+}
@@ -0,0 +1,56 @@
+package graceful
+
+import (
+	"context"
+	"time"
+)
+
+// SetShutdownPacing set shutdown pacing value.
+// shutdown process in the same batch is started one interval after another instead of all at once,
+// so heavy shutdown processes don't spike cpu and io together, they still run concurrently up to max shutdown process.
+// the interval is shrunk so the batch is fully started within half of the remaining shutdown time,
+// so pacing never prevents shutdown process from running. 0 or less disable it, which is the default value.
+func (g *Graceful) SetShutdownPacing(interval time.Duration) {
+	if interval < 0 {
+		interval = 0
+	}
+
+	g.shutdownPacing = interval
+}
+
+// pacingInterval get pacing interval between starts of count shutdown process, it's clamped to spread
+// the starts within half of the time left before ctx deadline.
+func (g *Graceful) pacingInterval(ctx context.Context, count int) time.Duration {
+	if g.shutdownPacing <= 0 || count < 2 {
+		return 0
+	}
+
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return g.shutdownPacing
+	}
+
+	limit := deadline.Sub(g.clock.Now()) / 2 / time.Duration(count-1)
+	if limit < g.shutdownPacing {
+		if limit < 0 {
+			return 0
+		}
+
+		return limit
+	}
+
+	return g.shutdownPacing
+}
+
+// waitPacing wait for pacing interval before starting the next shutdown process,
+// it's returned right away when ctx is done.
+func (g *Graceful) waitPacing(ctx context.Context, interval time.Duration) {
+	if interval <= 0 {
+		return
+	}
+
+	select {
+	case <-g.clock.After(interval):
+	case <-ctx.Done():
+	}
+}
@@ -0,0 +1,86 @@
+package graceful
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_SetShutdownPacing(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownPacing(50 * time.Millisecond)
+
+	var (
+		mutex  sync.Mutex
+		starts []time.Time
+	)
+
+	for i := 0; i < 3; i++ {
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			mutex.Lock()
+			starts = append(starts, time.Now())
+			mutex.Unlock()
+
+			return nil
+		})
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Len(t, starts, 3)
+	assert.Equal(t, 50*time.Millisecond, graceful.Config().ShutdownPacing)
+
+	sort.Slice(starts, func(i, j int) bool {
+		return starts[i].Before(starts[j])
+	})
+
+	for i := 1; i < len(starts); i++ {
+		assert.GreaterOrEqual(t, starts[i].Sub(starts[i-1]), 40*time.Millisecond)
+	}
+}
+
+func TestGraceful_SetShutdownPacingDeadline(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(200 * time.Millisecond)
+	graceful.SetShutdownPacing(time.Second)
+
+	var called int32
+
+	for i := 0; i < 3; i++ {
+		graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+			atomic.AddInt32(&called, 1)
+
+			return nil
+		})
+	}
+
+	startedAt := time.Now()
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(3), atomic.LoadInt32(&called))
+	assert.Less(t, time.Since(startedAt), 200*time.Millisecond)
+}
+
+func TestGraceful_pacingInterval(t *testing.T) {
+	graceful := New()
+
+	assert.Zero(t, graceful.pacingInterval(context.Background(), 3))
+
+	graceful.SetShutdownPacing(-time.Second)
+	assert.Zero(t, graceful.Config().ShutdownPacing)
+
+	graceful.SetShutdownPacing(time.Second)
+	assert.Zero(t, graceful.pacingInterval(context.Background(), 1))
+	assert.Equal(t, time.Second, graceful.pacingInterval(context.Background(), 3))
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	interval := graceful.pacingInterval(ctx, 3)
+	assert.Greater(t, interval, time.Duration(0))
+	assert.LessOrEqual(t, interval, 250*time.Millisecond)
+}
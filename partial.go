@@ -0,0 +1,51 @@
+package graceful
+
+import (
+	"context"
+)
+
+// ShutdownTags run only shutdown process with the given tags using the shutdown ordering,
+// and remove them from registered shutdown process so they're not run again on full shutdown.
+// it's bounded by ctx and max shutdown time, and returns error like Wait for just those shutdown process.
+// it doesn't change IsShuttingDown, and returns ErrShutdownStarted when full shutdown is already started.
+func (g *Graceful) ShutdownTags(ctx context.Context, tags ...string) error {
+	g.mutex.Lock()
+	if !g.shutdownStartedAt.IsZero() {
+		g.mutex.Unlock()
+
+		return ErrShutdownStarted
+	}
+
+	var selected, remaining []shutdown
+
+	for _, s := range g.shutdowns {
+		if containsString(tags, s.tag) {
+			selected = append(selected, s)
+		} else {
+			remaining = append(remaining, s)
+		}
+	}
+
+	g.shutdowns = append(make([]shutdown, 0, len(remaining)), remaining...)
+	g.mutex.Unlock()
+
+	if len(selected) == 0 {
+		return nil
+	}
+
+	shutdownCtx, shutdownCancel := g.clock.WithTimeout(ctx, g.maxShutdownTime)
+	defer shutdownCancel()
+
+	var (
+		run        = &shutdownRun{recorder: newHookRecorder(len(selected)), reportAll: g.reportAllErrors}
+		batches, _ = g.planShutdown(selected)
+	)
+
+	for _, batch := range batches {
+		if err := g.runShutdownBatch(shutdownCtx, batch, run); err != nil {
+			return run.result(err)
+		}
+	}
+
+	return run.result(nil)
+}
@@ -0,0 +1,42 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_ShutdownTags(t *testing.T) {
+	graceful := New()
+	graceful.SetCancelOnError(true)
+	graceful.SetShutdownPhases("ingress", "storage")
+
+	var (
+		calls       callRecorder
+		expectedErr = errors.New("flush failed")
+	)
+
+	graceful.RegisterShutdownProcessWithPhase(calls.hook("feature-cache"), "feature-cache", "storage")
+	graceful.RegisterShutdownProcessWithPhase(calls.hook("feature-api"), "feature-api", "ingress")
+	graceful.RegisterShutdownProcessWithTag(calls.hook("database"), "database")
+
+	err := graceful.ShutdownTags(context.Background(), "feature-cache", "feature-api", "unknown")
+
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"feature-api", "feature-cache"}, calls.list())
+	assert.False(t, graceful.IsShuttingDown())
+	assert.Len(t, graceful.Shutdowns(), 1)
+
+	graceful.RegisterShutdownProcessWithTag(calls.hookErr("feature-queue", expectedErr), "feature-queue")
+
+	err = graceful.ShutdownTags(context.Background(), "feature-queue")
+	assert.EqualError(t, err, "feature-queue: flush failed")
+
+	calls.reset()
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, []string{"database"}, calls.list())
+	assert.ErrorIs(t, graceful.ShutdownTags(context.Background(), "database"), ErrShutdownStarted)
+}
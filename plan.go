@@ -0,0 +1,217 @@
+package graceful
+
+import (
+	"context"
+	"sort"
+)
+
+// shutdownBatch shutdown process that run concurrently using the limit.
+type shutdownBatch struct {
+	phase     string
+	limit     int
+	shutdowns []shutdown
+}
+
+// planShutdown group shutdown process into batches that are run sequentially,
+// it uses shutdown scheduler when it's set, otherwise the batches are grouped by phase.
+// shutdown process that is not returned by shutdown scheduler is returned as unscheduled.
+func (g *Graceful) planShutdown(shutdowns []shutdown) (batches []shutdownBatch, unscheduled []shutdown) {
+	g.mutex.Lock()
+	scheduler, less := g.scheduler, g.shutdownLess
+	g.mutex.Unlock()
+
+	shutdowns = sortShutdowns(shutdowns, less)
+
+	if scheduler == nil {
+		return g.planShutdownPhases(shutdowns), nil
+	}
+
+	var (
+		infos       = make([]ShutdownInfo, 0, len(shutdowns))
+		byID        = make(map[string]shutdown, len(shutdowns))
+		scheduledID = make(map[string]bool, len(shutdowns))
+	)
+
+	for _, s := range shutdowns {
+		infos = append(infos, s.info())
+		byID[s.id] = s
+	}
+
+	for _, infoBatch := range scheduler(infos) {
+		batch := shutdownBatch{}
+
+		for _, info := range infoBatch {
+			s, ok := byID[info.ID]
+			if !ok || scheduledID[info.ID] {
+				continue
+			}
+
+			scheduledID[info.ID] = true
+			batch.shutdowns = append(batch.shutdowns, s)
+
+			if len(batch.shutdowns) == 1 {
+				batch.phase = s.phase
+			} else if batch.phase != s.phase {
+				batch.phase = ""
+			}
+		}
+
+		if len(batch.shutdowns) == 0 {
+			continue
+		}
+
+		batch.limit = g.phaseLimit(batch.phase)
+		batches = append(batches, batch)
+	}
+
+	for _, s := range shutdowns {
+		if !scheduledID[s.id] {
+			unscheduled = append(unscheduled, s)
+		}
+	}
+
+	return batches, unscheduled
+}
+
+// sortShutdowns get copy of shutdown process that is stable sorted using less, nil less keep the order.
+func sortShutdowns(shutdowns []shutdown, less func(a, b ShutdownInfo) bool) []shutdown {
+	if less == nil {
+		return shutdowns
+	}
+
+	sorted := append([]shutdown(nil), shutdowns...)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		return less(sorted[i].info(), sorted[j].info())
+	})
+
+	return sorted
+}
+
+// planShutdownPhases group shutdown process into batches by phase,
+// the batches are run sequentially in the order of shutdown phases after the connection drain, stop and flush phase,
+// and before the close phase.
+func (g *Graceful) planShutdownPhases(shutdowns []shutdown) []shutdownBatch {
+	g.mutex.Lock()
+
+	var (
+		phases  = append([]string{ConnectionDrainPhase, StopPhase, FlushPhase, ""}, g.phases...)
+		grouped = make(map[string][]shutdown)
+	)
+
+	g.mutex.Unlock()
+
+	for _, s := range shutdowns {
+		if _, ok := grouped[s.phase]; !ok && !containsString(phases, s.phase) && s.phase != ClosePhase {
+			phases = append(phases, s.phase)
+		}
+
+		grouped[s.phase] = append(grouped[s.phase], s)
+	}
+
+	// close phase is run after the phases that are not defined too, unless it's defined explicitly.
+	phases = append(phases, ClosePhase)
+
+	batches := make([]shutdownBatch, 0, len(grouped))
+
+	for _, phase := range phases {
+		phaseShutdowns, ok := grouped[phase]
+		if !ok {
+			continue
+		}
+
+		// phase is only planned once even when it's defined more than once.
+		delete(grouped, phase)
+
+		batches = append(batches, shutdownBatch{
+			phase:     phase,
+			limit:     g.phaseLimit(phase),
+			shutdowns: phaseShutdowns,
+		})
+	}
+
+	return batches
+}
+
+// phaseLimit get max shutdown process that can run concurrently on the phase.
+func (g *Graceful) phaseLimit(phase string) int {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if limit, ok := g.phaseConcurrency[phase]; ok {
+		return limit
+	}
+
+	return g.maxShutdownProcess
+}
+
+// planShutdownStages group shutdown process into batches in the order shutdown process run them,
+// the batches of reserved phases are placed after the other batches.
+func (g *Graceful) planShutdownStages(shutdowns []shutdown) []shutdownBatch {
+	others, reserved := splitReserved(shutdowns)
+	batches, _ := g.planShutdown(others)
+
+	for _, phase := range reservedPhases {
+		batches = append(batches, g.planShutdownPhases(reserved[phase])...)
+	}
+
+	return batches
+}
+
+// ShutdownPlan get copy of the batches of registered shutdown process in the order they'd be executed
+// without calling them, using the same planning as shutdown process, e.g. phases and shutdown scheduler.
+// shutdown process in the same batch is run concurrently, and shutdown process registered by another
+// shutdown process while it's running is not included since it's run in the next wave.
+func (g *Graceful) ShutdownPlan() [][]ShutdownInfo {
+	g.mutex.Lock()
+	shutdowns := append([]shutdown(nil), g.shutdowns...)
+	g.mutex.Unlock()
+
+	batches := g.planShutdownStages(shutdowns)
+	plan := make([][]ShutdownInfo, 0, len(batches))
+
+	for _, batch := range batches {
+		infos := make([]ShutdownInfo, 0, len(batch.shutdowns))
+
+		for _, s := range batch.shutdowns {
+			infos = append(infos, s.info())
+		}
+
+		plan = append(plan, infos)
+	}
+
+	return plan
+}
+
+// DryRun get tags of registered shutdown process in the order they'd be executed without calling them.
+// shutdown process in the same batch is run concurrently, so they're listed in batch order.
+// it returns nil when ctx is done.
+func (g *Graceful) DryRun(ctx context.Context) []string {
+	g.mutex.Lock()
+	shutdowns := append([]shutdown(nil), g.shutdowns...)
+	g.mutex.Unlock()
+
+	tags := make([]string, 0, len(shutdowns))
+
+	for _, batch := range g.planShutdownStages(shutdowns) {
+		if ctx.Err() != nil {
+			return nil
+		}
+
+		for _, s := range batch.shutdowns {
+			tags = append(tags, s.tag)
+		}
+	}
+
+	return tags
+}
+
+// containsString check whether value is in values.
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,169 @@
+package graceful
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_DryRun(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownPhases("ingress", "storage")
+
+	var called bool
+
+	process := func(ctx context.Context) error {
+		called = true
+		return nil
+	}
+
+	graceful.RegisterShutdownProcessWithPhase(process, "database", "storage")
+	graceful.RegisterShutdownProcessWithPhase(process, "cache", "cache")
+	graceful.RegisterShutdownProcessWithPhase(process, "http-server", "ingress")
+	graceful.RegisterShutdownProcessWithTag(process, "metrics")
+
+	assert.Equal(t, []string{"metrics", "http-server", "database", "cache"}, graceful.DryRun(context.Background()))
+	assert.False(t, called)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	assert.Nil(t, graceful.DryRun(ctx))
+}
+
+func TestGraceful_SetShutdownScheduler(t *testing.T) {
+	graceful := New()
+
+	process := func(ctx context.Context) error {
+		return nil
+	}
+
+	graceful.RegisterShutdownProcessWithTag(process, "first")
+	graceful.RegisterShutdownProcessWithTag(process, "second")
+	graceful.RegisterShutdownProcessWithTag(process, "third")
+
+	graceful.SetShutdownScheduler(func(hooks []ShutdownInfo) [][]ShutdownInfo {
+		assert.Len(t, hooks, 3)
+
+		return [][]ShutdownInfo{{hooks[2]}, {hooks[0], hooks[2]}}
+	})
+
+	assert.Equal(t, []string{"third", "first"}, graceful.DryRun(context.Background()))
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+	report := graceful.LastShutdownReport()
+
+	assert.Nil(t, err)
+	assert.Len(t, report.Hooks, 2)
+	assert.Equal(t, "third", report.Hooks[0].Tag)
+	assert.Equal(t, "first", report.Hooks[1].Tag)
+	assert.Len(t, report.Skipped, 1)
+	assert.Equal(t, SkipReasonUnscheduled, report.Skipped[0].Reason)
+}
+
+func TestGraceful_ShutdownPlan(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownPhases("ingress", "storage")
+
+	var called bool
+
+	process := func(ctx context.Context) error {
+		called = true
+		return nil
+	}
+
+	graceful.RegisterLogFlusher(process)
+	graceful.RegisterShutdownProcessWithPhase(process, "database", "storage")
+	graceful.RegisterShutdownProcessWithPhase(process, "http-server", "ingress")
+	graceful.RegisterShutdownProcessWithTag(process, "metrics")
+	graceful.RegisterShutdownProcessWithTag(process, "cache")
+	graceful.RegisterConnectionDrainer(process, "websocket")
+
+	plan := graceful.ShutdownPlan()
+
+	tags := make([][]string, 0, len(plan))
+	for _, batch := range plan {
+		var batchTags []string
+
+		for _, info := range batch {
+			batchTags = append(batchTags, info.Tag)
+		}
+
+		tags = append(tags, batchTags)
+	}
+
+	assert.Equal(t, [][]string{
+		{"websocket"},
+		{"metrics", "cache"},
+		{"http-server"},
+		{"database"},
+		{plan[4][0].ID},
+	}, tags)
+	assert.Equal(t, LogFlushPhase, plan[4][0].Phase)
+	assert.False(t, called)
+
+	plan[0][0].Tag = "changed"
+	assert.Equal(t, "websocket", graceful.ShutdownPlan()[0][0].Tag)
+
+	graceful.SetShutdownScheduler(func(hooks []ShutdownInfo) [][]ShutdownInfo {
+		return [][]ShutdownInfo{{hooks[len(hooks)-1]}}
+	})
+
+	plan = graceful.ShutdownPlan()
+	if assert.Len(t, plan, 2) {
+		assert.Equal(t, "websocket", plan[0][0].Tag)
+		assert.Equal(t, LogFlushPhase, plan[1][0].Phase)
+	}
+}
+
+func TestGraceful_SetShutdownLess(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownProcess(1)
+	graceful.SetShutdownLess(func(a, b ShutdownInfo) bool {
+		return a.Tag < b.Tag
+	})
+
+	var order []string
+
+	for _, tag := range []string{"cache", "queue", "database", "broker"} {
+		tag := tag
+
+		graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+			order = append(order, tag)
+
+			return nil
+		}, tag)
+	}
+
+	expected := []string{"broker", "cache", "database", "queue"}
+
+	assert.Equal(t, expected, graceful.DryRun(context.Background()))
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, expected, order)
+	assert.True(t, graceful.Config().ShutdownLess)
+}
+
+func TestGraceful_SetShutdownLessReset(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownLess(func(a, b ShutdownInfo) bool {
+		return a.Tag > b.Tag
+	})
+
+	process := func(ctx context.Context) error {
+		return nil
+	}
+
+	graceful.RegisterShutdownProcessWithTag(process, "first")
+	graceful.RegisterShutdownProcessWithTag(process, "second")
+
+	assert.Equal(t, []string{"second", "first"}, graceful.DryRun(context.Background()))
+
+	graceful.SetShutdownLess(nil)
+	assert.Equal(t, []string{"first", "second"}, graceful.DryRun(context.Background()))
+}
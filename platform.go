@@ -0,0 +1,93 @@
+package graceful
+
+import (
+	"os"
+	"runtime"
+	"syscall"
+
+	"github.com/rs/zerolog/log"
+)
+
+// maxSignal max signal number that can be handled on unix-like os.
+const maxSignal = 64
+
+// windowsSignals os signals that can be handled on windows, the others are never delivered.
+var windowsSignals = []os.Signal{os.Interrupt, syscall.SIGINT, syscall.SIGTERM}
+
+// supportedSignals drop os signals that can't be handled on the current os, e.g. SIGHUP on windows,
+// and log the skipped ones. skipped default signals are logged as debug since they're expected.
+// the default signals are used when all the given os signals are skipped, so signal handling is never silently disabled.
+func supportedSignals(signals []os.Signal, isDefault bool) []os.Signal {
+	supported, skipped := filterSignals(runtime.GOOS, signals)
+	if len(skipped) == 0 {
+		return supported
+	}
+
+	names := make([]string, 0, len(skipped))
+	for _, sig := range skipped {
+		names = append(names, signalName(sig))
+	}
+
+	event := log.Warn()
+	if isDefault {
+		event = log.Debug()
+	}
+
+	event.Str(platformTag, runtime.GOOS).Strs(skippedSignalsTag, names).Msg(unsupportedSignalMessage)
+
+	if len(supported) == 0 && !isDefault {
+		log.Warn().Str(platformTag, runtime.GOOS).Msg(noSupportedSignalMessage)
+
+		return supportedSignals(defaultSignals, true)
+	}
+
+	return supported
+}
+
+// filterSignals split os signals into the ones that can be handled on goos and the skipped ones.
+// SIGKILL can't be caught on any os, windows can only handle interrupt and SIGTERM,
+// and unix-like os can handle signal number up to maxSignal.
+func filterSignals(goos string, signals []os.Signal) (supported, skipped []os.Signal) {
+	for _, sig := range signals {
+		if isSupportedSignal(goos, sig) {
+			supported = append(supported, sig)
+		} else {
+			skipped = append(skipped, sig)
+		}
+	}
+
+	return supported, skipped
+}
+
+// isSupportedSignal check whether os signal can be handled on goos.
+func isSupportedSignal(goos string, sig os.Signal) bool {
+	if sig == nil || sig == os.Kill {
+		return false
+	}
+
+	switch goos {
+	case "windows":
+		for _, supported := range windowsSignals {
+			if sig == supported {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if number, ok := sig.(syscall.Signal); ok {
+		return number > 0 && number <= maxSignal
+	}
+
+	return true
+}
+
+// signalName get name of os signal, nil signal is named as nil.
+func signalName(sig os.Signal) string {
+	if sig == nil {
+		return "nil"
+	}
+
+	return sig.String()
+}
@@ -0,0 +1,49 @@
+package graceful
+
+import (
+	"os"
+	"runtime"
+	"strings"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterSignals(t *testing.T) {
+	signals := []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP, os.Kill, nil, syscall.Signal(1000)}
+
+	supported, skipped := filterSignals("linux", signals)
+	assert.Equal(t, []os.Signal{os.Interrupt, syscall.SIGTERM, syscall.SIGHUP}, supported)
+	assert.Equal(t, []os.Signal{os.Kill, nil, syscall.Signal(1000)}, skipped)
+
+	supported, skipped = filterSignals("windows", signals)
+	assert.Equal(t, []os.Signal{os.Interrupt, syscall.SIGTERM}, supported)
+	assert.Equal(t, []os.Signal{syscall.SIGHUP, os.Kill, nil, syscall.Signal(1000)}, skipped)
+}
+
+func TestNewWithContext_UnsupportedSignals(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := New(syscall.SIGTERM, os.Kill)
+
+	assert.Equal(t, []string{syscall.SIGTERM.String()}, graceful.Config().Signals)
+	assert.Equal(t, 1, strings.Count(logs.String(), unsupportedSignalMessage))
+	assert.Contains(t, logs.String(), `"skipped-signals":["killed"]`)
+}
+
+func TestNewWithContext_NoSupportedSignals(t *testing.T) {
+	logs := captureLogs(t)
+
+	supported, _ := filterSignals(runtime.GOOS, defaultSignals)
+	names := make([]string, 0, len(supported))
+
+	for _, sig := range supported {
+		names = append(names, sig.String())
+	}
+
+	assert.Equal(t, names, New(nil).Config().Signals)
+	assert.Equal(t, names, New(os.Kill).Config().Signals)
+
+	assert.Equal(t, 2, strings.Count(logs.String(), noSupportedSignalMessage))
+}
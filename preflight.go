@@ -0,0 +1,146 @@
+package graceful
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// RegisterPreflight register preflight check that is run by Wait before background processes are started,
+// e.g. to check the database is reachable. preflights are run sequentially in registration order using signal context,
+// the first error stop Wait right away without running shutdown process and it's returned wrapped with ErrPreflight,
+// the finalizers are still run.
+// background process registered after the first preflight is started only after all preflights are passed.
+func (g *Graceful) RegisterPreflight(preflight func(ctx context.Context) error) {
+	if preflight == nil {
+		checkNilProcess("RegisterPreflight")
+
+		return
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if g.state != stateIdle {
+		logRegisterError("RegisterPreflight", ErrAlreadyWaiting)
+
+		return
+	}
+
+	g.preflights = append(g.preflights, preflight)
+}
+
+// queuedProcess background process that is registered before all preflights are passed,
+// abort is called instead of process when it's never started.
+type queuedProcess struct {
+	process func() error
+	abort   func()
+}
+
+// runPreflights run all preflights sequentially and launch the queued background processes when all of them are passed.
+// the queued background processes are dropped when a preflight is failed.
+func (g *Graceful) runPreflights() error {
+	g.mutex.Lock()
+	preflights := append([]func(ctx context.Context) error(nil), g.preflights...)
+	g.mutex.Unlock()
+
+	for _, preflight := range preflights {
+		if err := preflight(g.signalCtx); err != nil {
+			for _, queued := range g.takeQueuedProcesses(false) {
+				queued.abort()
+				atomic.AddInt32(&g.runningProcesses, -1)
+			}
+
+			return wrapSentinel(ErrPreflight, err)
+		}
+	}
+
+	// the queued background processes are started by Wait, so it's blocked until a slot is free
+	// when there are more of them than process concurrency.
+	for _, queued := range g.takeQueuedProcesses(true) {
+		g.launchProcess(queued, true)
+	}
+
+	return nil
+}
+
+// takeQueuedProcesses take the queued background processes, and close launch when launched is true,
+// so background process registered afterwards is started right away.
+func (g *Graceful) takeQueuedProcesses(launched bool) []queuedProcess {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	queued := g.queuedProcesses
+	g.queuedProcesses = nil
+
+	if launched {
+		close(g.launch)
+	}
+
+	return queued
+}
+
+// pendingLaunch get launch channel that background process must wait for before it's started,
+// nil when no preflight is registered. must be called with mutex locked.
+func (g *Graceful) pendingLaunch() chan struct{} {
+	if len(g.preflights) == 0 {
+		return nil
+	}
+
+	return g.launch
+}
+
+// goProcess run background process in the group after all preflights are passed.
+func (g *Graceful) goProcess(process func() error) {
+	g.goAbortable(process, func() {})
+}
+
+// goAbortable run background process in the group after all preflights are passed,
+// abort is called instead when the process is never started, e.g. preflight is failed.
+func (g *Graceful) goAbortable(process func() error, abort func()) {
+	g.mutex.Lock()
+	launch := g.pendingLaunch()
+	g.mutex.Unlock()
+
+	g.goLaunched(launch, queuedProcess{process: process, abort: abort})
+}
+
+// goLaunched run background process in the group once launch is closed. until then it's queued
+// without taking a slot of process concurrency, so registering it is never blocked by the limit.
+func (g *Graceful) goLaunched(launch chan struct{}, queued queuedProcess) {
+	g.startProcess()
+
+	if launch != nil {
+		g.mutex.Lock()
+
+		select {
+		case <-launch:
+		default:
+			g.queuedProcesses = append(g.queuedProcesses, queued)
+			g.mutex.Unlock()
+
+			return
+		}
+
+		g.mutex.Unlock()
+	}
+
+	g.launchProcess(queued, false)
+}
+
+// launchProcess run background process in the group, the queued one is never started
+// when the group context is done before it takes a slot, e.g. os signal while Wait is blocked by the limit.
+func (g *Graceful) launchProcess(queued queuedProcess, wasQueued bool) {
+	g.group.Go(func() (err error) {
+		defer func() {
+			g.finishProcess(err)
+		}()
+
+		if wasQueued && g.groupCtx.Err() != nil {
+			queued.abort()
+
+			return nil
+		}
+
+		return queued.process()
+	})
+}
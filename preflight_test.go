@@ -0,0 +1,131 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterPreflight(t *testing.T) {
+	graceful := New()
+
+	var (
+		order   []string
+		started = make(chan struct{})
+	)
+
+	graceful.RegisterPreflight(func(ctx context.Context) error {
+		order = append(order, "config")
+		return nil
+	})
+
+	graceful.RegisterPreflight(func(ctx context.Context) error {
+		order = append(order, "database")
+		return nil
+	})
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		close(started)
+		<-ctx.Done()
+
+		return nil
+	})
+
+	go func() {
+		<-started
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	assert.Nil(t, graceful.Wait())
+	assert.Equal(t, []string{"config", "database"}, order)
+}
+
+func TestGraceful_RegisterPreflightFailed(t *testing.T) {
+	graceful := New()
+
+	var (
+		workerStarted, shutdownCalled, secondCalled int32
+		errUnreachable                              = errors.New("database unreachable")
+	)
+
+	graceful.RegisterPreflight(func(ctx context.Context) error {
+		return errUnreachable
+	})
+
+	graceful.RegisterPreflight(func(ctx context.Context) error {
+		atomic.StoreInt32(&secondCalled, 1)
+		return nil
+	})
+
+	graceful.RegisterProcess(func() error {
+		atomic.StoreInt32(&workerStarted, 1)
+		return nil
+	})
+
+	graceful.RegisterProcessWithTag(func(ctx context.Context) error {
+		atomic.StoreInt32(&workerStarted, 1)
+		return nil
+	}, "consumer")
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		atomic.StoreInt32(&shutdownCalled, 1)
+		return nil
+	})
+
+	err := graceful.Wait()
+
+	assert.ErrorIs(t, err, ErrPreflight)
+	assert.ErrorIs(t, err, errUnreachable)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&secondCalled))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&workerStarted))
+	assert.Equal(t, int32(0), atomic.LoadInt32(&shutdownCalled))
+}
+
+func TestGraceful_RegisterPreflightProcessConcurrency(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+	graceful.SetProcessConcurrency(1)
+
+	var (
+		started    int32
+		registered = make(chan struct{})
+		running    = make(chan struct{}, 2)
+	)
+
+	graceful.RegisterPreflight(func(ctx context.Context) error {
+		return nil
+	})
+
+	go func() {
+		defer close(registered)
+
+		for i := 0; i < 2; i++ {
+			graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+				atomic.AddInt32(&started, 1)
+				running <- struct{}{}
+				<-ctx.Done()
+
+				return nil
+			})
+		}
+	}()
+
+	select {
+	case <-registered:
+	case <-time.After(time.Second):
+		t.Fatal("register is blocked by process concurrency before preflights are passed")
+	}
+
+	go func() {
+		_ = graceful.Wait()
+	}()
+
+	<-running
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&started))
+}
@@ -0,0 +1,118 @@
+package graceful
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// SetProcessConcurrency set max number of background process that run concurrently,
+// register method is blocked until a running background process is returned when the limit is reached,
+// so registering many processes in a loop doesn't spike goroutines at startup. background process that
+// waits for preflights doesn't take a slot until it's started by Wait, which is blocked by the limit instead. it must be set before
+// any background process is registered, otherwise ErrProcessRunning is logged and the limit is kept.
+// 0 or less means no limit, which is the default value.
+func (g *Graceful) SetProcessConcurrency(limit int) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	if atomic.LoadInt32(&g.runningProcesses) > 0 {
+		logRegisterError("SetProcessConcurrency", ErrProcessRunning)
+
+		return
+	}
+
+	g.processConcurrency = limit
+	g.group.SetLimit(processLimit(limit))
+}
+
+// processLimit get errgroup limit of process concurrency, negative means no limit.
+func processLimit(limit int) int {
+	if limit <= 0 {
+		return -1
+	}
+
+	return limit
+}
+
+// RegisterProcessWithTag register running process to background with context param using tag,
+// so shutdown process can wait for it using RegisterShutdownAfterProcess.
+// duplicate tag is suffixed with its occurrence, e.g. writer#2, and ErrDuplicateTag is logged.
+func (g *Graceful) RegisterProcessWithTag(process func(ctx context.Context) error, tag string) {
+	const method = "RegisterProcessWithTag"
+
+	if process == nil {
+		checkNilProcess(method)
+
+		return
+	}
+
+	g.mutex.Lock()
+
+	if g.state == stateDone {
+		g.mutex.Unlock()
+		logRegisterError(method, ErrRegisterAfterShutdown)
+
+		return
+	}
+
+	tag = uniqueTag(method, tag, func(tag string) bool {
+		_, ok := g.processes[tag]
+
+		return ok
+	})
+
+	done := make(chan struct{})
+	g.processes[tag] = done
+	launch := g.pendingLaunch()
+	g.mutex.Unlock()
+
+	// the process is started outside the mutex, since it's blocked when process concurrency is reached.
+	g.goLaunched(launch, queuedProcess{
+		process: func() error {
+			defer close(done)
+
+			return g.processResult(process(g.groupCtx))
+		},
+		abort: func() {
+			close(done)
+		},
+	})
+}
+
+// RegisterShutdownAfterProcess register shutdown process using tag that is run only after
+// the background process with process tag is returned, or ctx is done.
+// the shutdown process is run right away when there is no background process with process tag.
+func (g *Graceful) RegisterShutdownAfterProcess(process func(context.Context) error, processTag, tag string) string {
+	if process == nil {
+		return g.registerShutdown("RegisterShutdownAfterProcess", newShutdown(tag, nil))
+	}
+
+	shutdownProcess := newShutdown(tag, func(ctx context.Context) error {
+		if err := g.waitProcess(ctx, processTag); err != nil {
+			return err
+		}
+
+		return process(ctx)
+	})
+	shutdownProcess.origin = process
+
+	return g.registerShutdown("RegisterShutdownAfterProcess", shutdownProcess)
+}
+
+// waitProcess wait until background process with tag is returned or ctx is done.
+func (g *Graceful) waitProcess(ctx context.Context, tag string) error {
+	g.mutex.Lock()
+	done, ok := g.processes[tag]
+	g.mutex.Unlock()
+
+	if !ok {
+		return nil
+	}
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,156 @@
+package graceful
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterShutdownAfterProcess(t *testing.T) {
+	graceful := New()
+
+	var (
+		exited   int32
+		observed bool
+		unknown  bool
+	)
+
+	graceful.RegisterProcessWithTag(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(200 * time.Millisecond)
+		atomic.StoreInt32(&exited, 1)
+
+		return nil
+	}, "writer")
+
+	graceful.RegisterProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "writer")
+
+	graceful.RegisterShutdownAfterProcess(func(ctx context.Context) error {
+		observed = atomic.LoadInt32(&exited) == 1
+
+		return nil
+	}, "writer", "close-file")
+
+	graceful.RegisterShutdownAfterProcess(func(ctx context.Context) error {
+		unknown = true
+
+		return nil
+	}, "unknown", "unknown-process")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.True(t, observed)
+	assert.True(t, unknown)
+}
+
+func TestGraceful_RegisterShutdownAfterProcessTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(2 * time.Second)
+	graceful.SetSoftShutdownTimeout(100 * time.Millisecond)
+
+	var shutdownCalled bool
+
+	graceful.RegisterProcessWithTag(func(ctx context.Context) error {
+		<-ctx.Done()
+		time.Sleep(300 * time.Millisecond)
+
+		return nil
+	}, "writer")
+
+	graceful.RegisterShutdownAfterProcess(func(ctx context.Context) error {
+		shutdownCalled = true
+
+		return nil
+	}, "writer", "close-file")
+
+	go func() {
+		sendSignal(syscall.SIGTERM)
+	}()
+
+	err := graceful.Wait()
+
+	assert.Nil(t, err)
+	assert.False(t, shutdownCalled)
+	assert.ErrorIs(t, graceful.LastShutdownReport().Hooks[0].Err, context.DeadlineExceeded)
+}
+
+func TestGraceful_RegisterProcessWithTagDuplicate(t *testing.T) {
+	logs := captureLogs(t)
+
+	graceful := NewFromContext(context.Background())
+
+	var started int32
+
+	for i := 0; i < 2; i++ {
+		graceful.RegisterProcessWithTag(func(ctx context.Context) error {
+			atomic.AddInt32(&started, 1)
+			<-ctx.Done()
+
+			return nil
+		}, "writer")
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&started))
+	assert.Contains(t, logs.String(), `"graceful-registered-tag":"writer#2"`)
+}
+
+func TestGraceful_SetProcessConcurrency(t *testing.T) {
+	graceful := New()
+	graceful.SetProcessConcurrency(2)
+
+	var (
+		running, maxRunning, finished int32
+		mutex                         sync.Mutex
+	)
+
+	for i := 0; i < 10; i++ {
+		graceful.RegisterProcess(func() error {
+			current := atomic.AddInt32(&running, 1)
+
+			mutex.Lock()
+			if current > maxRunning {
+				maxRunning = current
+			}
+			mutex.Unlock()
+
+			time.Sleep(5 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+			atomic.AddInt32(&finished, 1)
+
+			return nil
+		})
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(10), atomic.LoadInt32(&finished))
+	assert.LessOrEqual(t, maxRunning, int32(2))
+	assert.Equal(t, 2, graceful.Config().ProcessConcurrency)
+}
+
+func TestGraceful_SetProcessConcurrencyAfterRegister(t *testing.T) {
+	graceful := New()
+
+	graceful.RegisterProcessWithContext(func(ctx context.Context) error {
+		<-ctx.Done()
+
+		return nil
+	})
+
+	graceful.SetProcessConcurrency(1)
+	assert.Equal(t, 0, graceful.Config().ProcessConcurrency)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+}
@@ -0,0 +1,77 @@
+package graceful
+
+import (
+	"context"
+	"fmt"
+	"strings"
+)
+
+// ExpectReady declare process using tag that will mark readiness using MarkReady,
+// so WaitReady is waiting for it.
+func (g *Graceful) ExpectReady(tag string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.expectReady(tag)
+}
+
+// expectReady declare process readiness, must be called with mutex locked.
+func (g *Graceful) expectReady(tag string) {
+	if _, ok := g.readiness[tag]; ok {
+		return
+	}
+
+	g.readiness[tag] = false
+	g.readinessTags = append(g.readinessTags, tag)
+}
+
+// MarkReady mark process using tag as ready, process that is not declared using ExpectReady is declared as well.
+func (g *Graceful) MarkReady(tag string) {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.expectReady(tag)
+
+	if g.readiness[tag] {
+		return
+	}
+
+	g.readiness[tag] = true
+
+	close(g.readyChanged)
+	g.readyChanged = make(chan struct{})
+}
+
+// WaitReady wait until all declared processes are marked as ready or ctx is done,
+// it returns ErrNotReady with the tags of processes that are not ready when ctx is done.
+func (g *Graceful) WaitReady(ctx context.Context) error {
+	for {
+		g.mutex.Lock()
+		pending := g.pendingReady()
+		changed := g.readyChanged
+		g.mutex.Unlock()
+
+		if len(pending) == 0 {
+			return nil
+		}
+
+		select {
+		case <-changed:
+		case <-ctx.Done():
+			return fmt.Errorf("%w: %s", ErrNotReady, strings.Join(pending, ", "))
+		}
+	}
+}
+
+// pendingReady get tags of processes that are not ready in declaration order, must be called with mutex locked.
+func (g *Graceful) pendingReady() []string {
+	var pending []string
+
+	for _, tag := range g.readinessTags {
+		if !g.readiness[tag] {
+			pending = append(pending, tag)
+		}
+	}
+
+	return pending
+}
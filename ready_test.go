@@ -0,0 +1,46 @@
+package graceful
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_WaitReady(t *testing.T) {
+	graceful := New()
+
+	assert.Nil(t, graceful.WaitReady(context.Background()))
+
+	graceful.ExpectReady("http-server")
+	graceful.ExpectReady("consumer")
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		graceful.MarkReady("http-server")
+		graceful.MarkReady("http-server")
+
+		time.Sleep(50 * time.Millisecond)
+		graceful.MarkReady("consumer")
+	}()
+
+	assert.Nil(t, graceful.WaitReady(context.Background()))
+}
+
+func TestGraceful_WaitReadyTimeout(t *testing.T) {
+	graceful := New()
+
+	graceful.ExpectReady("http-server")
+	graceful.ExpectReady("consumer")
+	graceful.ExpectReady("cache")
+	graceful.MarkReady("http-server")
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	err := graceful.WaitReady(ctx)
+
+	assert.ErrorIs(t, err, ErrNotReady)
+	assert.EqualError(t, err, "graceful: not ready: consumer, cache")
+}
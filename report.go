@@ -0,0 +1,180 @@
+package graceful
+
+import (
+	"os"
+	"sync"
+	"time"
+)
+
+// ShutdownReport summary of the last shutdown process.
+type ShutdownReport struct {
+	// StartedAt time when shutdown process is started.
+	StartedAt time.Time `json:"started_at"`
+	// Total duration of all shutdown process.
+	Total time.Duration `json:"total"`
+	// Uptime duration since graceful is created until shutdown process is done.
+	Uptime time.Duration `json:"uptime"`
+	// EffectiveConcurrency number of shutdown process that can run concurrently,
+	// clamped to the number of registered shutdown process.
+	EffectiveConcurrency int `json:"effective_concurrency"`
+	// PeakConcurrency max observed number of shutdown process that run concurrently.
+	PeakConcurrency int `json:"peak_concurrency"`
+	// BlockedTime total time of shutdown process spent waiting for concurrency slot,
+	// significant value means raising the concurrency limit could speed up the shutdown.
+	BlockedTime time.Duration `json:"blocked_time"`
+	// ConcurrencySaturated shutdown process far exceed max shutdown process and they spent more time blocked
+	// waiting for concurrency slot than the whole shutdown process, so the shutdown is effectively serialized.
+	ConcurrencySaturated bool `json:"concurrency_saturated,omitempty"`
+	// BatchRetries number of retry attempts of failed shutdown process, see SetShutdownBatchRetry.
+	BatchRetries int `json:"batch_retries,omitempty"`
+	// Abandoned number of shutdown process that is still running when its shutdown context is done,
+	// see SetAbandonPolicy.
+	Abandoned int `json:"abandoned,omitempty"`
+	// Results result value of shutdown process registered using RegisterShutdownProcessWithResult by its tag.
+	Results map[string]interface{} `json:"results,omitempty"`
+	// Groups result of each shutdown process in the shutdown group by the group name in completion order,
+	// while the group result is listed in Hooks using the group name as tag.
+	Groups map[string][]HookReport `json:"groups,omitempty"`
+	// Hooks result of each shutdown process in completion order, retried shutdown process is listed once per attempt.
+	Hooks []HookReport `json:"hooks"`
+	// Skipped shutdown process that is not run and the reason.
+	Skipped []SkippedHook `json:"skipped,omitempty"`
+	// SignalCounts how many times each os signal is received by its name.
+	SignalCounts map[string]int `json:"signal_counts,omitempty"`
+	// EntryPoint entry point that drive the shutdown process, see EntryPointWait, EntryPointStop and EntryPointDrainNow.
+	EntryPoint string `json:"entry_point"`
+	// GoroutinesBefore, GoroutinesAfter and GoroutineDelta goroutine count at the start and the end
+	// of shutdown process, only filled when leak detection is enabled.
+	GoroutinesBefore int `json:"goroutines_before,omitempty"`
+	GoroutinesAfter  int `json:"goroutines_after,omitempty"`
+	GoroutineDelta   int `json:"goroutine_delta,omitempty"`
+}
+
+// saturationFactor how many times shutdown process must exceed max shutdown process to be saturated.
+const saturationFactor = 2
+
+// concurrencySaturated check whether the number of shutdown process far exceed the concurrency limit
+// and their total blocked time exceed the total shutdown time.
+func concurrencySaturated(hooks, limit int, blocked, total time.Duration) bool {
+	return limit > 0 && hooks > limit*saturationFactor && blocked > total
+}
+
+// HookReport result of single shutdown process.
+type HookReport struct {
+	ID           string        `json:"id"`
+	Tag          string        `json:"tag"`
+	RegisteredAt time.Time     `json:"registered_at"`
+	Duration     time.Duration `json:"duration"`
+	Retry        int           `json:"retry,omitempty"`
+	Err          error         `json:"-"`
+	Error        string        `json:"error,omitempty"`
+}
+
+// SkipReason reason why shutdown process is skipped.
+type SkipReason string
+
+const (
+	// SkipReasonAborted shutdown process is skipped because previous shutdown process is failed with cancel on error.
+	SkipReasonAborted SkipReason = "aborted"
+	// SkipReasonContextCancelled shutdown process is skipped because shutdown context is done before it's started.
+	SkipReasonContextCancelled SkipReason = "context-cancelled"
+	// SkipReasonUnscheduled shutdown process is skipped because it's not returned by shutdown scheduler.
+	SkipReasonUnscheduled SkipReason = "unscheduled"
+	// SkipReasonMaxWaves shutdown process is skipped because it's registered after max shutdown waves.
+	SkipReasonMaxWaves SkipReason = "max-waves"
+	// SkipReasonLimiter shutdown process is skipped because shutdown limiter is failed to acquire.
+	SkipReasonLimiter SkipReason = "limiter"
+	// SkipReasonSignal shutdown process is skipped because the shutdown is not triggered by its os signals.
+	SkipReasonSignal SkipReason = "signal"
+)
+
+// SkippedHook shutdown process that is not run.
+type SkippedHook struct {
+	ID     string     `json:"id"`
+	Tag    string     `json:"tag"`
+	Reason SkipReason `json:"reason"`
+}
+
+// newHookReport init hook report from shutdown data and its result.
+func newHookReport(s shutdown, duration time.Duration, err error) HookReport {
+	hook := HookReport{
+		ID:           s.id,
+		Tag:          s.tag,
+		RegisteredAt: s.registeredAt,
+		Duration:     duration,
+		Retry:        s.retry,
+		Err:          err,
+	}
+
+	if err != nil {
+		hook.Error = err.Error()
+	}
+
+	return hook
+}
+
+// signalCountsReport convert signal counts to be keyed by signal name.
+func signalCountsReport(counts map[os.Signal]int) map[string]int {
+	if len(counts) == 0 {
+		return nil
+	}
+
+	report := make(map[string]int, len(counts))
+	for sig, count := range counts {
+		report[sig.String()] += count
+	}
+
+	return report
+}
+
+// hookRecorder collect hook report from concurrent shutdown process.
+type hookRecorder struct {
+	hooks   []HookReport
+	skipped []SkippedHook
+	mutex   sync.Mutex
+}
+
+// newHookRecorder init hook recorder with capacity of hooks.
+func newHookRecorder(capacity int) *hookRecorder {
+	return &hookRecorder{
+		hooks: make([]HookReport, 0, capacity),
+	}
+}
+
+// add append hook report to recorder.
+func (r *hookRecorder) add(hook HookReport) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.hooks = append(r.hooks, hook)
+}
+
+// list get copy of recorded hook report.
+func (r *hookRecorder) list() []HookReport {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]HookReport(nil), r.hooks...)
+}
+
+// skip record shutdown process as skipped using the reason.
+func (r *hookRecorder) skip(shutdowns []shutdown, reason SkipReason) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	for _, s := range shutdowns {
+		r.skipped = append(r.skipped, SkippedHook{
+			ID:     s.id,
+			Tag:    s.tag,
+			Reason: reason,
+		})
+	}
+}
+
+// listSkipped get copy of recorded skipped shutdown process.
+func (r *hookRecorder) listSkipped() []SkippedHook {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return append([]SkippedHook(nil), r.skipped...)
+}
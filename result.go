@@ -0,0 +1,67 @@
+package graceful
+
+import (
+	"context"
+)
+
+// RegisterShutdownProcessWithResult register shutdown process using tag that report result value,
+// e.g. committed offset or number of flushed records. the result is recorded in the shutdown report
+// keyed by tag even when the shutdown process also returns error.
+func (g *Graceful) RegisterShutdownProcessWithResult(process func(ctx context.Context) (interface{}, error), tag string) string {
+	if process == nil {
+		return g.registerShutdown("RegisterShutdownProcessWithResult", newShutdown(tag, nil))
+	}
+
+	shutdownProcess := newShutdown(tag, func(ctx context.Context) error {
+		_, err := process(ctx)
+
+		return err
+	})
+	shutdownProcess.result = process
+
+	return g.registerShutdown("RegisterShutdownProcessWithResult", shutdownProcess)
+}
+
+// call call shutdown process and record its result value or its group report to run.
+func (s shutdown) call(ctx context.Context, run *shutdownRun) error {
+	switch {
+	case s.group != nil:
+		return s.group.run(ctx, run)
+	case s.result != nil:
+		result, err := s.result(ctx)
+		run.setResult(s.tag, result)
+
+		return err
+	default:
+		return s.process(ctx)
+	}
+}
+
+// setResult record result value of shutdown process tag.
+func (r *shutdownRun) setResult(tag string, result interface{}) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if r.results == nil {
+		r.results = make(map[string]interface{})
+	}
+
+	r.results[tag] = result
+}
+
+// resultsReport get copy of recorded result values, nil when no result is recorded.
+func (r *shutdownRun) resultsReport() map[string]interface{} {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	if len(r.results) == 0 {
+		return nil
+	}
+
+	results := make(map[string]interface{}, len(r.results))
+	for tag, result := range r.results {
+		results[tag] = result
+	}
+
+	return results
+}
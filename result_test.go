@@ -0,0 +1,35 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterShutdownProcessWithResult(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+
+	errCommit := errors.New("commit failed")
+
+	graceful.RegisterShutdownProcessWithResult(func(ctx context.Context) (interface{}, error) {
+		return int64(12345), nil
+	}, "consumer-offset")
+
+	graceful.RegisterShutdownProcessWithResult(func(ctx context.Context) (interface{}, error) {
+		return 10, errCommit
+	}, "outbox")
+
+	graceful.RegisterShutdownProcessWithTag(func(ctx context.Context) error {
+		return nil
+	}, "http-server")
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), errCommit)
+	assert.Equal(t, map[string]interface{}{
+		"consumer-offset": int64(12345),
+		"outbox":          10,
+	}, graceful.LastShutdownReport().Results)
+	assert.Empty(t, New().RegisterShutdownProcessWithResult(nil, "nil"))
+}
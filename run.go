@@ -0,0 +1,57 @@
+package graceful
+
+import (
+	"context"
+	"time"
+)
+
+// Option option to configure graceful on Run.
+type Option func(g *Graceful)
+
+// WithCancelOnError option to set cancel on error value.
+func WithCancelOnError(value bool) Option {
+	return func(g *Graceful) {
+		g.SetCancelOnError(value)
+	}
+}
+
+// WithReportAllErrors option to set report all errors value.
+func WithReportAllErrors(value bool) Option {
+	return func(g *Graceful) {
+		g.SetReportAllErrors(value)
+	}
+}
+
+// WithMaxShutdownTime option to set max shutdown time value.
+func WithMaxShutdownTime(duration time.Duration) Option {
+	return func(g *Graceful) {
+		g.SetMaxShutdownTime(duration)
+	}
+}
+
+// WithMaxShutdownProcess option to set max shutdown process value.
+func WithMaxShutdownProcess(max int) Option {
+	return func(g *Graceful) {
+		g.SetMaxShutdownProcess(max)
+	}
+}
+
+// Run initiate graceful using default signals, register all processes and shutdown processes,
+// then wait until all of them are done. it returns the same error as Wait.
+func Run(processes []func(context.Context) error, shutdowns []func(context.Context) error, opts ...Option) error {
+	g := New()
+
+	for _, opt := range opts {
+		opt(g)
+	}
+
+	for _, process := range processes {
+		g.RegisterProcessWithContext(process)
+	}
+
+	for _, shutdown := range shutdowns {
+		g.RegisterShutdownProcess(shutdown)
+	}
+
+	return g.Wait()
+}
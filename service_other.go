@@ -0,0 +1,9 @@
+//go:build !windows
+
+package graceful
+
+// RunAsWindowsService run Wait as windows service using the service name,
+// it just calls Wait on non windows platform.
+func (g *Graceful) RunAsWindowsService(_ string) error {
+	return g.Wait()
+}
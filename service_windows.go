@@ -0,0 +1,67 @@
+//go:build windows
+
+package graceful
+
+import (
+	"golang.org/x/sys/windows/svc"
+)
+
+// windowsService windows service handler that bridge service control event into shutdown trigger.
+type windowsService struct {
+	graceful *Graceful
+	err      error
+}
+
+// RunAsWindowsService run Wait as windows service using the service name,
+// service stop and shutdown control event will trigger shutdown process like os signal does.
+// it just calls Wait when the app is not running as windows service.
+func (g *Graceful) RunAsWindowsService(name string) error {
+	isService, err := svc.IsWindowsService()
+	if err != nil {
+		return err
+	}
+
+	if !isService {
+		return g.Wait()
+	}
+
+	service := &windowsService{graceful: g}
+	if err = svc.Run(name, service); err != nil {
+		return err
+	}
+
+	return service.err
+}
+
+// Execute handle windows service control event until Wait returns.
+func (s *windowsService) Execute(_ []string, requests <-chan svc.ChangeRequest, changes chan<- svc.Status) (bool, uint32) {
+	errChan := make(chan error, 1)
+
+	go func() {
+		errChan <- s.graceful.Wait()
+	}()
+
+	changes <- svc.Status{State: svc.Running, Accepts: svc.AcceptStop | svc.AcceptShutdown}
+
+	for {
+		select {
+		case s.err = <-errChan:
+			changes <- svc.Status{State: svc.StopPending}
+
+			if s.err != nil {
+				return false, 1
+			}
+
+			return false, 0
+		case request := <-requests:
+			switch request.Cmd {
+			case svc.Interrogate:
+				changes <- request.CurrentStatus
+			case svc.Stop, svc.Shutdown:
+				changes <- svc.Status{State: svc.StopPending}
+
+				s.graceful.trigger()
+			}
+		}
+	}
+}
@@ -2,30 +2,71 @@ package graceful
 
 import (
 	"context"
-
-	"github.com/google/uuid"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
 )
 
 // shutdown data struct that define for shutdown process
 type shutdown struct {
-	id      uuid.UUID
-	tag     string
-	process func(context.Context) error
+	id           string
+	tag          string
+	phase        string
+	registeredAt time.Time
+	retry        int
+	process      func(context.Context) error
+	// result shutdown process that report result value, see RegisterShutdownProcessWithResult.
+	result func(context.Context) (interface{}, error)
+	// group shutdown group that is run as this shutdown process, see RegisterShutdownGroup.
+	group *ShutdownGroup
+	// origin registered function supplied by user before it's wrapped, used to detect duplicate functions.
+	origin func(context.Context) error
+	// signals os signals that the shutdown process is scoped to, see RegisterShutdownProcessForSignals.
+	signals []os.Signal
+}
+
+// ShutdownInfo registered shutdown process metadata.
+type ShutdownInfo struct {
+	ID           string    `json:"id"`
+	Tag          string    `json:"tag"`
+	Phase        string    `json:"phase,omitempty"`
+	RegisteredAt time.Time `json:"registered_at"`
 }
 
-// newShutdown init shutdown data using defined params
-func newShutdown(tag string, process func(ctx context.Context) error) (shutdown, uuid.UUID) {
-	id := uuid.New()
+// info get metadata of shutdown process.
+func (s shutdown) info() ShutdownInfo {
+	return ShutdownInfo{
+		ID:           s.id,
+		Tag:          s.tag,
+		Phase:        s.phase,
+		RegisteredAt: s.registeredAt,
+	}
+}
 
-	if tag == "" {
-		tag = id.String()
+// newShutdown init shutdown data using defined params, id is filled on register.
+func newShutdown(tag string, process func(ctx context.Context) error) shutdown {
+	return shutdown{
+		tag:          tag,
+		registeredAt: time.Now(),
+		process:      process,
 	}
+}
+
+// newUserShutdown init shutdown data using function supplied by user, so it's checked for duplicate functions.
+func newUserShutdown(tag string, process func(ctx context.Context) error) shutdown {
+	shutdownProcess := newShutdown(tag, process)
+	shutdownProcess.origin = process
+
+	return shutdownProcess
+}
 
-	s := shutdown{
-		id:      id,
-		tag:     tag,
-		process: process,
+// newID generate random hex id using crypto rand.
+func newID() string {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		panic(err)
 	}
 
-	return s, id
+	return hex.EncodeToString(b)
 }
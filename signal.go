@@ -0,0 +1,318 @@
+package graceful
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+)
+
+// signalWatcher watch os signal persistently until it's stopped,
+// the first signal cancel the signal context and all signals are counted.
+type signalWatcher struct {
+	sigChan  chan os.Signal
+	injected chan injectedSignal
+	cancel   context.CancelFunc
+	counts   map[os.Signal]int
+	first    os.Signal
+	firstAt  time.Time
+	onSignal func(os.Signal)
+	onRepeat func(os.Signal, time.Duration)
+	veto     func(os.Signal) bool
+	stopped  chan struct{}
+	done     chan struct{}
+	once     sync.Once
+	mutex    sync.Mutex
+}
+
+// injectedSignal os signal that is injected without the os, done is closed once it's handled.
+type injectedSignal struct {
+	sig  os.Signal
+	done chan struct{}
+}
+
+// newSignalWatcher init signal context from ctx and start watching the signals.
+func newSignalWatcher(ctx context.Context, signals []os.Signal) (*signalWatcher, context.Context) {
+	signalCtx, cancel := context.WithCancel(ctx)
+
+	w := &signalWatcher{
+		sigChan:  make(chan os.Signal, 1),
+		injected: make(chan injectedSignal),
+		cancel:   cancel,
+		counts:   make(map[os.Signal]int),
+		stopped:  make(chan struct{}),
+		done:     make(chan struct{}),
+	}
+
+	signal.Notify(w.sigChan, signals...)
+
+	go w.watch()
+
+	return w, signalCtx
+}
+
+// watch count incoming signal and cancel the signal context until the watcher is stopped.
+// when shutdown veto is set, the signal context is cancelled only when the veto proceed,
+// and another signal while the veto is still running bypass the veto.
+func (w *signalWatcher) watch() {
+	defer close(w.done)
+
+	var (
+		vetoing    bool
+		vetoResult = make(chan os.Signal, 1)
+	)
+
+	receive := func(sig os.Signal) {
+		w.mutex.Lock()
+		w.counts[sig]++
+		onSignal, onRepeat, veto, accepted := w.onSignal, w.onRepeat, w.veto, w.first != nil
+		sinceFirst := time.Since(w.firstAt)
+		w.mutex.Unlock()
+
+		if onSignal != nil {
+			onSignal(sig)
+		}
+
+		if accepted && onRepeat != nil {
+			onRepeat(sig, sinceFirst)
+		}
+
+		if vetoing || veto == nil || accepted {
+			w.accept(sig)
+
+			return
+		}
+
+		vetoing = true
+
+		go func() {
+			if veto(sig) {
+				vetoResult <- sig
+			} else {
+				vetoResult <- nil
+			}
+		}()
+	}
+
+	for {
+		select {
+		case sig := <-w.sigChan:
+			receive(sig)
+		case injected := <-w.injected:
+			receive(injected.sig)
+			close(injected.done)
+		case sig := <-vetoResult:
+			vetoing = false
+
+			if sig != nil {
+				w.accept(sig)
+			}
+		case <-w.stopped:
+			return
+		}
+	}
+}
+
+// inject handle os signal like it's received from the os and wait until it's handled,
+// it returns false when the watcher is already stopped.
+func (w *signalWatcher) inject(sig os.Signal) bool {
+	injected := injectedSignal{sig: sig, done: make(chan struct{})}
+
+	select {
+	case w.injected <- injected:
+		<-injected.done
+
+		return true
+	case <-w.stopped:
+		return false
+	}
+}
+
+// accept record the first accepted signal and cancel the signal context.
+func (w *signalWatcher) accept(sig os.Signal) {
+	w.mutex.Lock()
+	if w.first == nil {
+		w.first = sig
+		w.firstAt = time.Now()
+	}
+	w.mutex.Unlock()
+
+	w.cancel()
+}
+
+// stop stop watching the signals and cancel the signal context.
+func (w *signalWatcher) stop() {
+	w.stopTimeout(signalWatcherStopTimeout)
+}
+
+// stopTimeout stop watching the signals and wait the watcher goroutine to exit up to timeout,
+// it returns false when the goroutine is not exited in time.
+func (w *signalWatcher) stopTimeout(timeout time.Duration) bool {
+	w.once.Do(func() {
+		signal.Stop(w.sigChan)
+		close(w.stopped)
+		w.cancel()
+	})
+
+	timer := time.NewTimer(timeout)
+	defer timer.Stop()
+
+	select {
+	case <-w.done:
+		return true
+	case <-timer.C:
+		return false
+	}
+}
+
+// setOnSignal set callback that is called for every received signal.
+func (w *signalWatcher) setOnSignal(onSignal func(os.Signal)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.onSignal = onSignal
+}
+
+// setOnRepeat set callback that is called for every signal received after the first accepted signal,
+// with the elapsed time since the first accepted signal.
+func (w *signalWatcher) setOnRepeat(onRepeat func(os.Signal, time.Duration)) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.onRepeat = onRepeat
+}
+
+// setVeto set shutdown veto that is called before cancelling the signal context.
+func (w *signalWatcher) setVeto(veto func(os.Signal) bool) {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	w.veto = veto
+}
+
+// firstSignal get the first accepted signal, nil when no signal is accepted.
+func (w *signalWatcher) firstSignal() os.Signal {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return w.first
+}
+
+// signalCounts get copy of received signal counts.
+func (w *signalWatcher) signalCounts() map[os.Signal]int {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	counts := make(map[os.Signal]int, len(w.counts))
+	for sig, count := range w.counts {
+		counts[sig] = count
+	}
+
+	return counts
+}
+
+// SignalCounts get how many times each os signal is received.
+func (g *Graceful) SignalCounts() map[os.Signal]int {
+	if g.signalWatcher == nil {
+		return make(map[os.Signal]int)
+	}
+
+	return g.signalWatcher.signalCounts()
+}
+
+// InjectSignal deliver os signal to graceful like it's received from the os without signaling the current process,
+// e.g. to test the signal handling, and return once the signal is handled. it returns false when sig is not handled,
+// like graceful from NewFromContext, or the signal handling is already stopped.
+func (g *Graceful) InjectSignal(sig os.Signal) bool {
+	if g.signalWatcher == nil || !containsSignal(g.signals, sig) {
+		return false
+	}
+
+	return g.signalWatcher.inject(sig)
+}
+
+// SetShutdownVeto set shutdown veto that is called on os signal before shutdown is triggered,
+// returning false keep the app running and handle the next os signal, true proceed the shutdown.
+// another os signal while the veto is still running bypass the veto, e.g. to force exit.
+// it has no effect on graceful from NewFromContext, nil veto will reset it.
+func (g *Graceful) SetShutdownVeto(veto func(sig os.Signal) bool) {
+	g.shutdownVeto = veto
+
+	if g.signalWatcher != nil {
+		g.signalWatcher.setVeto(veto)
+	}
+}
+
+// SignalLoopStopped check whether the internal signal goroutine is stopped cleanly when Wait returns,
+// it's false when Wait is not returned yet or the goroutine is not stopped within the bounded wait.
+func (g *Graceful) SignalLoopStopped() bool {
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	return g.signalLoopStopped
+}
+
+// stopSignal stop handling os signal and record whether the signal goroutine is stopped cleanly.
+func (g *Graceful) stopSignal() {
+	stopped := true
+
+	if g.signalWatcher != nil {
+		stopped = g.signalWatcher.stopTimeout(signalWatcherStopTimeout)
+	} else {
+		g.signalCancel()
+	}
+
+	g.mutex.Lock()
+	defer g.mutex.Unlock()
+
+	g.signalLoopStopped = stopped
+}
+
+// TriggeringSignal get the os signal that trigger the shutdown process, ok is false when the shutdown
+// is not triggered by os signal yet, e.g. it's triggered by Stop or parent context cancellation.
+func (g *Graceful) TriggeringSignal() (sig os.Signal, ok bool) {
+	if g.signalWatcher == nil {
+		return nil, false
+	}
+
+	sig = g.signalWatcher.firstSignal()
+
+	return sig, sig != nil
+}
+
+// RegisterShutdownProcessForSignals register shutdown process using tag that is only run when the shutdown
+// is triggered by one of signals, see TriggeringSignal, e.g. cleanup that is only needed on SIGTERM and not on SIGHUP.
+// it's skipped when the shutdown is not triggered by os signal, and empty signals run it on any trigger.
+func (g *Graceful) RegisterShutdownProcessForSignals(process func(context.Context) error, tag string, signals ...os.Signal) string {
+	shutdownProcess := newUserShutdown(tag, process)
+	shutdownProcess.signals = append([]os.Signal(nil), signals...)
+
+	return g.registerShutdown("RegisterShutdownProcessForSignals", shutdownProcess)
+}
+
+// matchSignal split shutdown process that is scoped to the triggering signal from the unmatched ones.
+func (g *Graceful) matchSignal(shutdowns []shutdown) (matched, unmatched []shutdown) {
+	sig, ok := g.TriggeringSignal()
+
+	for _, s := range shutdowns {
+		if len(s.signals) == 0 || (ok && containsSignal(s.signals, sig)) {
+			matched = append(matched, s)
+		} else {
+			unmatched = append(unmatched, s)
+		}
+	}
+
+	return matched, unmatched
+}
+
+// containsSignal check whether signals contains sig.
+func containsSignal(signals []os.Signal, sig os.Signal) bool {
+	for _, s := range signals {
+		if s == sig {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,70 @@
+package graceful
+
+import (
+	"context"
+	"syscall"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterShutdownProcessForSignals(t *testing.T) {
+	graceful := New()
+
+	var calls callRecorder
+
+	graceful.RegisterShutdownProcessForSignals(calls.hook("reload"), "reload", syscall.SIGHUP)
+	graceful.RegisterShutdownProcessForSignals(calls.hook("terminate"), "terminate", syscall.SIGTERM, syscall.SIGINT)
+	graceful.RegisterShutdownProcessForSignals(calls.hook("any"), "any")
+
+	assert.True(t, graceful.InjectSignal(syscall.SIGHUP))
+
+	assert.Nil(t, graceful.Wait())
+	assert.ElementsMatch(t, []string{"reload", "any"}, calls.list())
+
+	sig, ok := graceful.TriggeringSignal()
+	assert.True(t, ok)
+	assert.Equal(t, syscall.SIGHUP, sig)
+
+	skipped := graceful.LastShutdownReport().Skipped
+	if assert.Len(t, skipped, 1) {
+		assert.Equal(t, "terminate", skipped[0].Tag)
+		assert.Equal(t, SkipReasonSignal, skipped[0].Reason)
+	}
+}
+
+func TestGraceful_RegisterShutdownProcessForSignalsWithoutSignal(t *testing.T) {
+	graceful := New()
+
+	var called bool
+
+	graceful.RegisterShutdownProcessForSignals(func(ctx context.Context) error {
+		called = true
+
+		return nil
+	}, "terminate", syscall.SIGTERM)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.False(t, called)
+
+	_, ok := graceful.TriggeringSignal()
+	assert.False(t, ok)
+
+	_, ok = NewFromContext(context.Background()).TriggeringSignal()
+	assert.False(t, ok)
+}
+
+func TestGraceful_InjectSignal(t *testing.T) {
+	graceful := New(syscall.SIGTERM)
+
+	assert.False(t, graceful.InjectSignal(syscall.SIGHUP))
+	assert.True(t, graceful.InjectSignal(syscall.SIGTERM))
+	assert.Equal(t, 1, graceful.SignalCounts()[syscall.SIGTERM])
+	assert.Nil(t, graceful.Wait())
+
+	sig, ok := graceful.TriggeringSignal()
+	assert.True(t, ok)
+	assert.Equal(t, syscall.SIGTERM, sig)
+	assert.False(t, graceful.InjectSignal(syscall.SIGTERM))
+	assert.False(t, NewFromContext(context.Background()).InjectSignal(syscall.SIGTERM))
+}
@@ -0,0 +1,47 @@
+package graceful
+
+import (
+	"context"
+)
+
+const (
+	// stopTagSuffix suffix of stop function tag of RegisterStopClose.
+	stopTagSuffix = "/stop"
+	// closeTagSuffix suffix of close function tag of RegisterStopClose.
+	closeTagSuffix = "/close"
+)
+
+// RegisterStopClose register two-phase shutdown process of resource that stop accepting new work, then close it.
+// stopFunc is run on StopPhase before most of the other shutdown process, and closeFunc is run on ClosePhase after them,
+// so all resources are quiesced before any of them is torn down. they're registered using tag with "/stop"
+// and "/close" suffix, so the result of both phases is reported per tag. nothing is registered when any of them
+// can't be registered, e.g. nil function or after shutdown is done.
+func (g *Graceful) RegisterStopClose(stopFunc, closeFunc func(ctx context.Context) error, tag string) (stopID, closeID string) {
+	const method = "RegisterStopClose"
+
+	if stopFunc == nil || closeFunc == nil {
+		checkNilProcess(method)
+
+		return "", ""
+	}
+
+	stopProcess := newUserShutdown(tag+stopTagSuffix, stopFunc)
+	stopProcess.phase = StopPhase
+
+	stopID = g.registerShutdown(method, stopProcess)
+	if stopID == "" {
+		return "", ""
+	}
+
+	closeProcess := newUserShutdown(tag+closeTagSuffix, closeFunc)
+	closeProcess.phase = ClosePhase
+
+	closeID = g.registerShutdown(method, closeProcess)
+	if closeID == "" {
+		g.Unregister(stopID)
+
+		return "", ""
+	}
+
+	return stopID, closeID
+}
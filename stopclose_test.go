@@ -0,0 +1,83 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterStopClose(t *testing.T) {
+	graceful := New()
+	graceful.SetShutdownPhases("storage")
+
+	var calls callRecorder
+
+	stopID, closeID := graceful.RegisterStopClose(calls.hook("consumer-stop"), calls.hook("consumer-close"), "consumer")
+	assert.NotEmpty(t, stopID)
+	assert.NotEmpty(t, closeID)
+
+	graceful.RegisterStopClose(calls.hook("pool-stop"), calls.hook("pool-close"), "pool")
+	graceful.RegisterShutdownProcessWithPhase(calls.hook("storage"), "database", "storage")
+	graceful.RegisterShutdownProcessWithPhase(calls.hook("undefined"), "cache", "undefined")
+	graceful.RegisterShutdownProcessWithPhase(calls.hook("flush"), "buffer", FlushPhase)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	called := calls.list()
+	assert.Len(t, called, 7)
+	assert.ElementsMatch(t, []string{"consumer-stop", "pool-stop"}, called[:2])
+	assert.Equal(t, []string{"flush", "storage", "undefined"}, called[2:5])
+	assert.ElementsMatch(t, []string{"consumer-close", "pool-close"}, called[5:])
+
+	var tags []string
+	for _, hook := range graceful.LastShutdownReport().Hooks {
+		tags = append(tags, hook.Tag)
+	}
+
+	assert.Subset(t, tags, []string{"consumer/stop", "consumer/close", "pool/stop", "pool/close"})
+}
+
+func TestGraceful_RegisterStopCloseFailed(t *testing.T) {
+	graceful := New()
+	graceful.SetReportAllErrors(true)
+
+	stopErr := errors.New("stop err")
+
+	graceful.RegisterStopClose(func(ctx context.Context) error {
+		return stopErr
+	}, func(ctx context.Context) error {
+		return nil
+	}, "consumer")
+
+	assert.ErrorIs(t, graceful.Stop(context.Background()), stopErr)
+
+	for _, hook := range graceful.LastShutdownReport().Hooks {
+		if hook.Tag == "consumer/stop" {
+			assert.ErrorIs(t, hook.Err, stopErr)
+		} else {
+			assert.Equal(t, "consumer/close", hook.Tag)
+			assert.Nil(t, hook.Err)
+		}
+	}
+}
+
+func TestGraceful_RegisterStopCloseInvalid(t *testing.T) {
+	graceful := New()
+
+	noop := func(ctx context.Context) error {
+		return nil
+	}
+
+	stopID, closeID := graceful.RegisterStopClose(nil, noop, "nil-stop")
+	assert.Empty(t, stopID)
+	assert.Empty(t, closeID)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	stopID, closeID = graceful.RegisterStopClose(noop, noop, "consumer")
+	assert.Empty(t, stopID)
+	assert.Empty(t, closeID)
+	assert.Empty(t, graceful.Shutdowns())
+}
@@ -0,0 +1,233 @@
+// Package supervisor run graceful lifecycle under restart supervision, so a supervised sub-service
+// is restarted on failure instead of just returning, while the core package is unaffected.
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"sync"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// DefaultMaxRestarts default value for max restarts.
+	DefaultMaxRestarts = 3
+	// restartsTag add restart count on restart log.
+	restartsTag = "restarts"
+	// restartMessage default message when graceful is restarted.
+	restartMessage = "graceful is stopped, restarting it"
+)
+
+var (
+	// ErrNilGraceful factory returned nil graceful.
+	ErrNilGraceful = errors.New("supervisor: nil graceful")
+	// ErrMaxRestarts graceful is stopped again after max restarts is reached, it wraps the last Wait error.
+	ErrMaxRestarts = errors.New("supervisor: max restarts reached")
+)
+
+// Supervisor run Wait of graceful from factory and restart it with a new graceful using restart policy,
+// until it's stopped by os signal, the context is done or max restarts is reached.
+type Supervisor struct {
+	factory     func() *graceful.Graceful
+	signals     []os.Signal
+	policy      func(err error) bool
+	maxRestarts int
+	delay       time.Duration
+	restarts    int
+	mutex       sync.Mutex
+}
+
+// New init supervisor that create graceful using factory for every run, the given os signals
+// stop the supervisor entirely, the default signals are used when there is no signal.
+func New(factory func() *graceful.Graceful, signals ...os.Signal) *Supervisor {
+	if len(signals) == 0 {
+		signals = graceful.DefaultSignals()
+	}
+
+	return &Supervisor{
+		factory:     factory,
+		signals:     signals,
+		policy:      RestartOnError,
+		maxRestarts: DefaultMaxRestarts,
+	}
+}
+
+// SetRestartPolicy set restart policy that decide whether graceful is restarted using Wait error,
+// nil policy will reset it to RestartOnError.
+func (s *Supervisor) SetRestartPolicy(policy func(err error) bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if policy == nil {
+		policy = RestartOnError
+	}
+
+	s.policy = policy
+}
+
+// SetMaxRestarts set max restarts value, 0 never restart and negative value restart without limit.
+func (s *Supervisor) SetMaxRestarts(max int) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.maxRestarts = max
+}
+
+// SetRestartDelay set delay before graceful is restarted, 0 or less restart right away, which is the default value.
+func (s *Supervisor) SetRestartDelay(delay time.Duration) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	s.delay = delay
+}
+
+// Restarts get number of restarts of the current or the last run.
+func (s *Supervisor) Restarts() int {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	return s.restarts
+}
+
+// RestartOnError restart policy that restart graceful when Wait returns error, it's the default policy.
+func RestartOnError(err error) bool {
+	return err != nil
+}
+
+// RestartOnErrors get restart policy that restart graceful when Wait error match one of targets using errors.Is.
+func RestartOnErrors(targets ...error) func(err error) bool {
+	return func(err error) bool {
+		for _, target := range targets {
+			if errors.Is(err, target) {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// RestartOnExitCodes get restart policy that restart graceful when exit code of Wait error is one of codes,
+// exit code is mapped the same way as SetExitCode, nil mapping use graceful.DefaultExitCode.
+func RestartOnExitCodes(mapping func(err error) int, codes ...int) func(err error) bool {
+	if mapping == nil {
+		mapping = graceful.DefaultExitCode
+	}
+
+	return func(err error) bool {
+		code := mapping(err)
+
+		for _, c := range codes {
+			if c == code {
+				return true
+			}
+		}
+
+		return false
+	}
+}
+
+// Run run Wait of graceful from factory and restart it by restart policy, it returns the last Wait error
+// once graceful is stopped by os signal or ctx is done, restart policy doesn't restart it, or max restarts
+// is reached, wrapped with ErrMaxRestarts on the last one. graceful that doesn't handle the os signal,
+// e.g. from NewFromContext, is stopped using Stop on os signal or ctx done.
+func (s *Supervisor) Run(ctx context.Context) error {
+	if s.factory == nil {
+		return ErrNilGraceful
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, s.signals...)
+	defer stop()
+
+	s.mutex.Lock()
+	s.restarts = 0
+	s.mutex.Unlock()
+
+	for {
+		g := s.factory()
+		if g == nil {
+			return ErrNilGraceful
+		}
+
+		err := s.wait(ctx, g)
+
+		// os signal of the graceful itself is passed through to stop the supervisor too.
+		if _, ok := g.TriggeringSignal(); ok || ctx.Err() != nil {
+			return err
+		}
+
+		s.mutex.Lock()
+		policy, maxRestarts, delay, restarts := s.policy, s.maxRestarts, s.delay, s.restarts
+		s.mutex.Unlock()
+
+		if !policy(err) {
+			return err
+		}
+
+		if maxRestarts >= 0 && restarts >= maxRestarts {
+			return fmt.Errorf("%w: %w", ErrMaxRestarts, err)
+		}
+
+		s.mutex.Lock()
+		s.restarts++
+		s.mutex.Unlock()
+
+		log.Warn().Err(err).Int(restartsTag, restarts+1).Msg(restartMessage)
+
+		if delay > 0 {
+			timer := time.NewTimer(delay)
+
+			select {
+			case <-timer.C:
+			case <-ctx.Done():
+				timer.Stop()
+
+				return err
+			}
+		}
+	}
+}
+
+// wait run Wait of g and stop it when ctx is done first, Stop is called only after Wait is started,
+// otherwise Stop would start its own Wait.
+func (s *Supervisor) wait(ctx context.Context, g *graceful.Graceful) error {
+	var (
+		started = make(chan struct{})
+		done    = make(chan struct{})
+		once    sync.Once
+	)
+
+	g.OnStart(func() {
+		once.Do(func() {
+			close(started)
+		})
+	})
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case <-done:
+			return
+		}
+
+		select {
+		case <-started:
+			// graceful that handles the os signal is already stopped by it.
+			if _, ok := g.TriggeringSignal(); !ok {
+				_ = g.Stop(context.Background())
+			}
+		case <-done:
+		}
+	}()
+
+	err := g.Wait()
+	close(done)
+
+	return err
+}
@@ -0,0 +1,152 @@
+package supervisor
+
+import (
+	"context"
+	"errors"
+	"os"
+	"syscall"
+	"testing"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSupervisor_Run(t *testing.T) {
+	var (
+		runs       int
+		processErr = errors.New("process err")
+	)
+
+	supervisor := New(func() *graceful.Graceful {
+		runs++
+
+		g := graceful.NewFromContext(context.Background())
+		g.RegisterProcess(func() error {
+			return processErr
+		})
+
+		return g
+	})
+	supervisor.SetMaxRestarts(2)
+
+	err := supervisor.Run(context.Background())
+
+	assert.ErrorIs(t, err, ErrMaxRestarts)
+	assert.ErrorIs(t, err, processErr)
+	assert.Equal(t, 3, runs)
+	assert.Equal(t, 2, supervisor.Restarts())
+}
+
+func TestSupervisor_RunRestartPolicy(t *testing.T) {
+	var runs int
+
+	supervisor := New(func() *graceful.Graceful {
+		runs++
+
+		g := graceful.NewFromContext(context.Background())
+		g.RegisterProcess(func() error {
+			if runs == 1 {
+				return errors.New("process err")
+			}
+
+			return graceful.ErrStopRequested
+		})
+
+		return g
+	})
+	supervisor.SetMaxRestarts(-1)
+	supervisor.SetRestartDelay(10 * time.Millisecond)
+
+	assert.Nil(t, supervisor.Run(context.Background()))
+	assert.Equal(t, 2, runs)
+	assert.Equal(t, 1, supervisor.Restarts())
+}
+
+func TestSupervisor_RunContextDone(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+
+	var runs int
+
+	supervisor := New(func() *graceful.Graceful {
+		runs++
+
+		g := graceful.NewFromContext(context.Background())
+		g.RegisterProcessWithContext(func(ctx context.Context) error {
+			<-ctx.Done()
+
+			return ctx.Err()
+		})
+
+		return g
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		cancel()
+	}()
+
+	assert.Nil(t, supervisor.Run(ctx))
+	assert.Equal(t, 1, runs)
+	assert.Zero(t, supervisor.Restarts())
+}
+
+func TestSupervisor_RunSignal(t *testing.T) {
+	var runs int
+
+	supervisor := New(func() *graceful.Graceful {
+		runs++
+
+		g := graceful.New(syscall.SIGTERM)
+		g.SetReportAllErrors(true)
+		g.RegisterShutdownProcess(func(ctx context.Context) error {
+			return errors.New("shutdown err")
+		})
+
+		return g
+	}, syscall.SIGTERM)
+
+	go func() {
+		p, err := os.FindProcess(os.Getpid())
+		if err != nil {
+			panic(err)
+		}
+
+		time.Sleep(100 * time.Millisecond)
+		_ = p.Signal(syscall.SIGTERM)
+	}()
+
+	assert.ErrorContains(t, supervisor.Run(context.Background()), "shutdown err")
+	assert.Equal(t, 1, runs)
+}
+
+func TestSupervisor_RunNilGraceful(t *testing.T) {
+	assert.ErrorIs(t, New(nil).Run(context.Background()), ErrNilGraceful)
+	assert.ErrorIs(t, New(func() *graceful.Graceful {
+		return nil
+	}).Run(context.Background()), ErrNilGraceful)
+}
+
+func TestRestartPolicies(t *testing.T) {
+	timeoutErr := errors.New("timeout err")
+
+	assert.True(t, RestartOnError(timeoutErr))
+	assert.False(t, RestartOnError(nil))
+
+	onErrors := RestartOnErrors(graceful.ErrShutdownTimeout, timeoutErr)
+	assert.True(t, onErrors(timeoutErr))
+	assert.True(t, onErrors(graceful.ErrShutdownTimeout))
+	assert.False(t, onErrors(errors.New("other err")))
+
+	onExitCodes := RestartOnExitCodes(func(err error) int {
+		if errors.Is(err, timeoutErr) {
+			return 2
+		}
+
+		return graceful.DefaultExitCode(err)
+	}, 2)
+	assert.True(t, onExitCodes(timeoutErr))
+	assert.False(t, onExitCodes(errors.New("other err")))
+	assert.False(t, RestartOnExitCodes(nil, 1)(nil))
+	assert.True(t, RestartOnExitCodes(nil, 1)(timeoutErr))
+}
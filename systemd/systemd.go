@@ -0,0 +1,99 @@
+// Package systemd integrate graceful with systemd service notification for Type=notify service,
+// so systemd TimeoutStopSec cooperates with the shutdown process.
+package systemd
+
+import (
+	"net"
+	"os"
+	"strconv"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/rs/zerolog/log"
+)
+
+const (
+	// notifySocketEnv env var that hold systemd notify socket path.
+	notifySocketEnv = "NOTIFY_SOCKET"
+	// Ready state to tell systemd the service is ready.
+	Ready = "READY=1"
+	// Stopping state to tell systemd the service is stopping.
+	Stopping = "STOPPING=1"
+
+	// stateTag add systemd state on notify error.
+	stateTag = "systemd-state"
+	// notifyErrorMessage default message when systemd notify is failed.
+	notifyErrorMessage = "failed to notify systemd"
+)
+
+// Notify send state to systemd notify socket from NOTIFY_SOCKET env,
+// it does nothing when the env is not set.
+func Notify(state string) error {
+	socket := os.Getenv(notifySocketEnv)
+	if socket == "" {
+		return nil
+	}
+
+	// socket that start with @ is on abstract namespace.
+	if socket[0] == '@' {
+		socket = "\x00" + socket[1:]
+	}
+
+	conn, err := net.DialUnix("unixgram", nil, &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		return err
+	}
+
+	defer conn.Close()
+
+	_, err = conn.Write([]byte(state))
+
+	return err
+}
+
+// ExtendTimeout get state to extend systemd stop timeout by duration.
+func ExtendTimeout(duration time.Duration) string {
+	return "EXTEND_TIMEOUT_USEC=" + strconv.FormatInt(duration.Microseconds(), 10)
+}
+
+// Register send READY=1 when Wait is started and STOPPING=1 when shutdown is started.
+// when interval is greater than 0, EXTEND_TIMEOUT_USEC is sent every interval during shutdown,
+// extending stop timeout by twice the interval, until Wait returns.
+func Register(g *graceful.Graceful, interval time.Duration) {
+	g.OnStart(func() {
+		notify(Ready)
+	})
+
+	g.OnShutdownStart(func() {
+		notify(Stopping)
+
+		if interval <= 0 {
+			return
+		}
+
+		notify(ExtendTimeout(2 * interval))
+
+		go func() {
+			ticker := time.NewTicker(interval)
+			defer ticker.Stop()
+
+			done := g.PostShutdownContext().Done()
+
+			for {
+				select {
+				case <-ticker.C:
+					notify(ExtendTimeout(2 * interval))
+				case <-done:
+					return
+				}
+			}
+		}()
+	})
+}
+
+// notify send state and log the error.
+func notify(state string) {
+	if err := Notify(state); err != nil {
+		log.Error().Err(err).Str(stateTag, state).Msg(notifyErrorMessage)
+	}
+}
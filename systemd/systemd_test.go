@@ -0,0 +1,72 @@
+package systemd
+
+import (
+	"context"
+	"net"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/erry-az/go-graceful"
+	"github.com/stretchr/testify/assert"
+)
+
+func listen(t *testing.T) *net.UnixConn {
+	socket := filepath.Join(t.TempDir(), "notify.sock")
+
+	conn, err := net.ListenUnixgram("unixgram", &net.UnixAddr{Name: socket, Net: "unixgram"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv(notifySocketEnv, socket)
+
+	return conn
+}
+
+func read(t *testing.T, conn *net.UnixConn) string {
+	buf := make([]byte, 256)
+
+	_ = conn.SetReadDeadline(time.Now().Add(time.Second))
+
+	n, err := conn.Read(buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return string(buf[:n])
+}
+
+func TestNotify(t *testing.T) {
+	t.Setenv(notifySocketEnv, "")
+	assert.Nil(t, Notify(Ready))
+
+	conn := listen(t)
+	defer conn.Close()
+
+	assert.Nil(t, Notify(Ready))
+	assert.Equal(t, Ready, read(t, conn))
+}
+
+func TestRegister(t *testing.T) {
+	conn := listen(t)
+	defer conn.Close()
+
+	g := graceful.New()
+	Register(g, 50*time.Millisecond)
+
+	g.RegisterShutdownProcess(func(ctx context.Context) error {
+		time.Sleep(100 * time.Millisecond)
+		return nil
+	})
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		_ = g.Stop(context.Background())
+	}()
+
+	assert.Nil(t, g.Wait())
+	assert.Equal(t, Ready, read(t, conn))
+	assert.Equal(t, Stopping, read(t, conn))
+	assert.Equal(t, ExtendTimeout(100*time.Millisecond), read(t, conn))
+}
@@ -0,0 +1,77 @@
+package graceful
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/zerolog/log"
+)
+
+// RegisterTicker register background process that call tick on every interval until shutdown is triggered
+// or other background process returns error,
+// and shutdown process using tag that wait until the current tick is finished, so no tick is fired during teardown.
+// tick context is cancelled when the shutdown process context is done before the current tick is finished,
+// and tick error is logged without stopping the ticker.
+func (g *Graceful) RegisterTicker(interval time.Duration, tick func(ctx context.Context) error, tag string) string {
+	const method = "RegisterTicker"
+
+	if tick == nil {
+		checkNilProcess(method)
+
+		return ""
+	}
+
+	if interval <= 0 {
+		logRegisterError(method, fmt.Errorf("%w: %s", ErrInvalidInterval, interval))
+
+		return ""
+	}
+
+	var (
+		done                = make(chan struct{})
+		tickCtx, tickCancel = context.WithCancel(context.Background())
+	)
+
+	id := g.registerShutdown(method, newShutdown(tag, func(ctx context.Context) error {
+		select {
+		case <-done:
+			return nil
+		case <-ctx.Done():
+			tickCancel()
+
+			return ctx.Err()
+		}
+	}))
+	if id == "" {
+		tickCancel()
+
+		return ""
+	}
+
+	g.RegisterProcessWithContext(func(ctx context.Context) error {
+		defer close(done)
+		defer tickCancel()
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ctx.Done():
+				return nil
+			case <-ticker.C:
+				// shutdown can be triggered while the tick is ready, so it's checked again before firing.
+				if ctx.Err() != nil {
+					return nil
+				}
+
+				if err := tick(tickCtx); err != nil {
+					log.Error().Str(tickerTag, tag).Err(err).Send()
+				}
+			}
+		}
+	})
+
+	return id
+}
@@ -0,0 +1,113 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterTicker(t *testing.T) {
+	graceful := New()
+
+	var (
+		ticks, finished int32
+		ticking         = make(chan struct{}, 1)
+	)
+
+	id := graceful.RegisterTicker(10*time.Millisecond, func(ctx context.Context) error {
+		if atomic.AddInt32(&ticks, 1) == 1 {
+			ticking <- struct{}{}
+			time.Sleep(200 * time.Millisecond)
+		}
+
+		atomic.AddInt32(&finished, 1)
+
+		return nil
+	}, "report")
+
+	assert.NotEmpty(t, id)
+
+	<-ticking
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&ticks))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&finished))
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&ticks))
+}
+
+func TestGraceful_RegisterTickerTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+
+	ticking := make(chan struct{}, 1)
+
+	graceful.RegisterTicker(10*time.Millisecond, func(ctx context.Context) error {
+		select {
+		case ticking <- struct{}{}:
+		default:
+		}
+
+		<-ctx.Done()
+
+		return ctx.Err()
+	}, "report")
+
+	<-ticking
+
+	err := graceful.Stop(context.Background())
+
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.Empty(t, graceful.RegisterTicker(0, func(ctx context.Context) error {
+		return nil
+	}, "invalid"))
+}
+
+func TestGraceful_RegisterTickerProcessError(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+
+	var (
+		expectedErr = errors.New("failed")
+		ticking     = make(chan struct{}, 1)
+		ticks       int32
+	)
+
+	graceful.RegisterTicker(10*time.Millisecond, func(ctx context.Context) error {
+		atomic.AddInt32(&ticks, 1)
+
+		select {
+		case ticking <- struct{}{}:
+		default:
+		}
+
+		return nil
+	}, "report")
+	graceful.RegisterProcess(func() error {
+		<-ticking
+
+		return expectedErr
+	})
+
+	done := make(chan error, 1)
+
+	go func() {
+		done <- graceful.Wait()
+	}()
+
+	select {
+	case err := <-done:
+		assert.ErrorIs(t, err, expectedErr)
+	case <-time.After(time.Second):
+		t.Fatal("Wait is blocked by the ticker")
+	}
+
+	stopped := atomic.LoadInt32(&ticks)
+
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, stopped, atomic.LoadInt32(&ticks))
+}
@@ -0,0 +1,70 @@
+package graceful
+
+import (
+	"fmt"
+	"time"
+)
+
+// WatchTrigger poll check on every interval and trigger shutdown process when it returns true,
+// e.g. when sentinel file appears on platform without os signal. it complements os signal handling,
+// and the polling is stopped once shutdown is triggered or Wait returns.
+func (g *Graceful) WatchTrigger(check func() bool, interval time.Duration) {
+	const method = "WatchTrigger"
+
+	if check == nil {
+		checkNilProcess(method)
+
+		return
+	}
+
+	if interval <= 0 {
+		logRegisterError(method, fmt.Errorf("%w: %s", ErrInvalidInterval, interval))
+
+		return
+	}
+
+	g.mutex.Lock()
+	if g.state == stateDone {
+		g.mutex.Unlock()
+		logRegisterError(method, ErrRegisterAfterShutdown)
+
+		return
+	}
+
+	// signal context is done once shutdown is triggered, and it's always cancelled when Wait returns.
+	trigger, signaled := g.trigger, g.signalCtx.Done()
+	g.mutex.Unlock()
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-signaled:
+				return
+			case <-ticker.C:
+				// shutdown can be triggered while the tick is ready, so it's checked again before polling.
+				if isClosed(signaled) {
+					return
+				}
+
+				if check() {
+					trigger()
+
+					return
+				}
+			}
+		}
+	}()
+}
+
+// isClosed check whether done channel is closed without blocking.
+func isClosed(done <-chan struct{}) bool {
+	select {
+	case <-done:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,60 @@
+package graceful
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_WatchTrigger(t *testing.T) {
+	graceful := New()
+
+	var (
+		degraded int32
+		checks   int32
+		called   bool
+	)
+
+	graceful.RegisterShutdownProcess(func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+
+	graceful.WatchTrigger(func() bool {
+		atomic.AddInt32(&checks, 1)
+
+		return atomic.LoadInt32(&degraded) == 1
+	}, 10*time.Millisecond)
+
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		atomic.StoreInt32(&degraded, 1)
+	}()
+
+	assert.Nil(t, graceful.Wait())
+	assert.True(t, called)
+
+	polled := atomic.LoadInt32(&checks)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, polled, atomic.LoadInt32(&checks))
+}
+
+func TestGraceful_WatchTriggerStopped(t *testing.T) {
+	graceful := New()
+
+	var checks int32
+
+	graceful.WatchTrigger(func() bool {
+		atomic.AddInt32(&checks, 1)
+		return false
+	}, 10*time.Millisecond)
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+
+	polled := atomic.LoadInt32(&checks)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, polled, atomic.LoadInt32(&checks))
+}
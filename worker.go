@@ -0,0 +1,145 @@
+package graceful
+
+import (
+	"context"
+	"sync"
+
+	"github.com/rs/zerolog/log"
+)
+
+// WorkerPool pool of workers that process submitted jobs in the background,
+// it stops accepting new job when shutdown is started and the queued jobs are drained as shutdown process.
+type WorkerPool struct {
+	tag      string
+	jobs     chan interface{}
+	closed   bool
+	stopping chan struct{}
+	once     sync.Once
+	done     chan struct{}
+	ctx      context.Context
+	cancel   context.CancelFunc
+	handler  func(ctx context.Context, job interface{}) error
+	mutex    sync.RWMutex
+}
+
+// RegisterWorkerPool register number of workers that call handler for each submitted job,
+// and shutdown process using tag that stop accepting new job and wait until workers finish the queued jobs.
+// handler context is cancelled when the shutdown process context is done before the jobs are drained,
+// and handler error is logged without stopping the worker.
+func (g *Graceful) RegisterWorkerPool(workers int, handler func(ctx context.Context, job interface{}) error, tag string) *WorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	pool := &WorkerPool{
+		tag:      tag,
+		jobs:     make(chan interface{}, workers),
+		stopping: make(chan struct{}),
+		done:     make(chan struct{}),
+		ctx:      ctx,
+		cancel:   cancel,
+		handler:  handler,
+	}
+
+	if handler == nil {
+		checkNilProcess("RegisterWorkerPool")
+		pool.close()
+		cancel()
+
+		return pool
+	}
+
+	if g.registerShutdown("RegisterWorkerPool", newShutdown(tag, pool.drain)) == "" {
+		pool.close()
+		cancel()
+
+		return pool
+	}
+
+	var workerGroup sync.WaitGroup
+
+	workerGroup.Add(workers)
+
+	// the worker that is never started, e.g. preflight is failed, is done right away, so the pool is still done.
+	for i := 0; i < workers; i++ {
+		g.goAbortable(func() error {
+			defer workerGroup.Done()
+
+			pool.work()
+
+			return nil
+		}, workerGroup.Done)
+	}
+
+	go func() {
+		workerGroup.Wait()
+		close(pool.done)
+		cancel()
+	}()
+
+	// the jobs are closed once the lifecycle is done even when the shutdown process is skipped,
+	// so the workers never block Wait.
+	g.RegisterFinalizer(pool.close)
+
+	return pool
+}
+
+// Submit queue job to be processed by the workers, it's blocked when all workers are busy
+// and ok is false when the pool is already stopped accepting new job.
+func (p *WorkerPool) Submit(job interface{}) (ok bool) {
+	p.mutex.RLock()
+	defer p.mutex.RUnlock()
+
+	if p.closed {
+		return false
+	}
+
+	select {
+	case p.jobs <- job:
+		return true
+	case <-p.stopping:
+		return false
+	}
+}
+
+// work process queued jobs until the jobs is closed.
+func (p *WorkerPool) work() {
+	for job := range p.jobs {
+		if err := p.handler(p.ctx, job); err != nil {
+			log.Error().Str(workerPoolTag, p.tag).Err(err).Send()
+		}
+	}
+}
+
+// close stop accepting new job, blocked submit is released first so it doesn't block the closing.
+func (p *WorkerPool) close() {
+	p.once.Do(func() {
+		close(p.stopping)
+	})
+
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.closed {
+		return
+	}
+
+	p.closed = true
+	close(p.jobs)
+}
+
+// drain stop accepting new job and wait until workers finish the queued jobs or ctx is done.
+func (p *WorkerPool) drain(ctx context.Context) error {
+	p.close()
+
+	select {
+	case <-p.done:
+		return nil
+	case <-ctx.Done():
+		p.cancel()
+
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,111 @@
+package graceful
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGraceful_RegisterWorkerPool(t *testing.T) {
+	graceful := New()
+
+	var processed int32
+
+	pool := graceful.RegisterWorkerPool(2, func(ctx context.Context, job interface{}) error {
+		time.Sleep(50 * time.Millisecond)
+		atomic.AddInt32(&processed, int32(job.(int)))
+
+		return errors.New("logged")
+	}, "jobs")
+
+	for i := 0; i < 6; i++ {
+		assert.True(t, pool.Submit(1))
+	}
+
+	assert.Nil(t, graceful.Stop(context.Background()))
+	assert.Equal(t, int32(6), atomic.LoadInt32(&processed))
+	assert.False(t, pool.Submit(1))
+}
+
+func TestGraceful_RegisterWorkerPoolTimeout(t *testing.T) {
+	graceful := New()
+	graceful.SetMaxShutdownTime(100 * time.Millisecond)
+
+	var cancelled int32
+
+	pool := graceful.RegisterWorkerPool(1, func(ctx context.Context, job interface{}) error {
+		<-ctx.Done()
+		atomic.StoreInt32(&cancelled, 1)
+
+		return ctx.Err()
+	}, "jobs")
+
+	assert.True(t, pool.Submit(1))
+	assert.True(t, pool.Submit(2))
+
+	submitted := make(chan bool, 1)
+
+	go func() {
+		// blocked until the pool is stopped.
+		submitted <- pool.Submit(3)
+	}()
+
+	err := graceful.Stop(context.Background())
+
+	assert.ErrorIs(t, err, ErrShutdownTimeout)
+	assert.False(t, <-submitted)
+	assert.Eventually(t, func() bool {
+		return atomic.LoadInt32(&cancelled) == 1
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestGraceful_RegisterWorkerPoolPreflightFailed(t *testing.T) {
+	graceful := NewFromContext(context.Background())
+
+	var (
+		handled   int32
+		finalized int32
+	)
+
+	graceful.RegisterPreflight(func(ctx context.Context) error {
+		return errors.New("database unreachable")
+	})
+
+	pool := graceful.RegisterWorkerPool(2, func(ctx context.Context, job interface{}) error {
+		atomic.AddInt32(&handled, 1)
+
+		return nil
+	}, "worker")
+
+	graceful.RegisterFinalizer(func() {
+		atomic.StoreInt32(&finalized, 1)
+	})
+
+	assert.ErrorIs(t, graceful.Wait(), ErrPreflight)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&finalized))
+
+	submitted := make(chan bool, 1)
+
+	go func() {
+		submitted <- pool.Submit("job")
+	}()
+
+	select {
+	case ok := <-submitted:
+		assert.False(t, ok)
+	case <-time.After(time.Second):
+		t.Fatal("submit is blocked after failed preflight")
+	}
+
+	select {
+	case <-pool.done:
+	case <-time.After(time.Second):
+		t.Fatal("worker pool is never done after failed preflight")
+	}
+
+	assert.Equal(t, int32(0), atomic.LoadInt32(&handled))
+}